@@ -0,0 +1,226 @@
+// Package conformance checks that a local_days.LocalDaysCalculator fulfills the invariants that are documented on the interface.
+// It's used to test the zone based implementation against all IANA time zones but you may also use it to test your own implementations of local_days.LocalDaysCalculator.
+package conformance
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hochfrequenz/go-local-days/local_days"
+)
+
+// Violation describes a single invariant of a local_days.LocalDaysCalculator method that does not hold for a specific timestamp.
+type Violation struct {
+	// Method is the name of the LocalDaysCalculator method that violates the invariant
+	Method string
+	// Timestamp is the input for which the invariant does not hold
+	Timestamp time.Time
+	// Description describes the violated invariant
+	Description string
+}
+
+func (v Violation) Error() string {
+	return fmt.Sprintf("%s(%s): %s", v.Method, v.Timestamp.Format(time.RFC3339Nano), v.Description)
+}
+
+// Check checks the invariants of all LocalDaysCalculator methods for timestamps from all local days of the years fromYear to toYear (both inclusive) and returns all violations found.
+// The timestamps are sampled at the start, in the middle and at the end of each local day. If the calculator provides its location (see local_days.Location), they are also sampled around each change of the UTC offset, where local days are most likely to go wrong.
+// An empty result means that the calculator conforms.
+// The methods of local_days.LocalWeeksCalculator and local_days.BusinessDaysCalculator are checked, too, if the calculator implements them.
+func Check(calculator local_days.LocalDaysCalculator, fromYear int, toYear int) []Violation {
+	c := checker{calculator: calculator}
+	c.weeks, _ = calculator.(local_days.LocalWeeksCalculator)
+	c.businessDays, _ = calculator.(local_days.BusinessDaysCalculator)
+	location := local_days.Location(calculator)
+	end := time.Date(toYear+1, 1, 1, 0, 0, 0, 0, time.UTC)
+	startOfDay := calculator.StartOfLocalDay(time.Date(fromYear, 1, 1, 0, 0, 0, 0, time.UTC))
+	for dayIndex := 0; startOfDay.Before(end); dayIndex++ {
+		startOfNextDay := calculator.StartOfNextLocalDay(startOfDay)
+		if !startOfNextDay.After(startOfDay) {
+			c.report("StartOfNextLocalDay", startOfDay, "the start of the next local day %s is not after the start of the local day", startOfNextDay)
+			// we don't trust the calculator anymore to iterate over the days, so we skip an hour to get out of the loop
+			startOfDay = calculator.StartOfLocalDay(startOfDay.Add(time.Hour))
+			continue
+		}
+		middleOfDay := startOfDay.Add(startOfNextDay.Sub(startOfDay) / 2)
+		for _, timestamp := range []time.Time{startOfDay, middleOfDay, startOfNextDay.Add(-time.Nanosecond)} {
+			c.checkTimestamp(timestamp)
+		}
+		// checking all weekdays on all days would be slow, so we rotate through the weekdays
+		c.checkNextLocalWeekday(middleOfDay, time.Weekday(dayIndex%7))
+		if location != nil {
+			for _, transition := range local_days.OffsetTransitions(location, startOfDay, startOfNextDay) {
+				for _, distance := range transitionSampleDistances {
+					c.checkTimestamp(transition.Add(distance))
+					c.checkNextLocalWeekday(transition.Add(distance), time.Weekday(dayIndex%7))
+				}
+			}
+		}
+		startOfDay = startOfNextDay
+	}
+	return c.violations
+}
+
+// transitionSampleDistances are the distances from a change of the UTC offset at which timestamps are sampled.
+var transitionSampleDistances = []time.Duration{-time.Hour, -time.Second, -time.Nanosecond, 0, time.Second, time.Hour}
+
+// checkTimestamp checks the invariants of all methods (except NextLocalWeekday) for timestamp.
+func (c *checker) checkTimestamp(timestamp time.Time) {
+	c.checkLocalDay(timestamp)
+	c.checkLocalMonth(timestamp)
+	if c.weeks != nil {
+		c.checkLocalWeek(timestamp)
+	}
+	if c.businessDays != nil {
+		c.checkBusinessDay(timestamp)
+	}
+	c.checkAddLocalDays(timestamp)
+}
+
+type checker struct {
+	calculator local_days.LocalDaysCalculator
+	// weeks is the calculator if it implements local_days.LocalWeeksCalculator, nil otherwise
+	weeks local_days.LocalWeeksCalculator
+	// businessDays is the calculator if it implements local_days.BusinessDaysCalculator, nil otherwise
+	businessDays local_days.BusinessDaysCalculator
+	violations   []Violation
+}
+
+func (c *checker) report(method string, timestamp time.Time, format string, args ...interface{}) {
+	c.violations = append(c.violations, Violation{Method: method, Timestamp: timestamp, Description: fmt.Sprintf(format, args...)})
+}
+
+// checkUTC checks the convention that all times returned by a LocalDaysCalculator are in UTC and without monotonic clock reading (see local_days.ValidateTimestamp).
+func (c *checker) checkUTC(method string, timestamp time.Time, result time.Time) {
+	if err := local_days.ValidateTimestamp(result); err != nil {
+		c.report(method, timestamp, "the result is not normalized: %v", err)
+	}
+}
+
+func (c *checker) checkLocalDay(timestamp time.Time) {
+	startOfDay := c.calculator.StartOfLocalDay(timestamp)
+	startOfNextDay := c.calculator.StartOfNextLocalDay(timestamp)
+	c.checkUTC("StartOfLocalDay", timestamp, startOfDay)
+	c.checkUTC("StartOfNextLocalDay", timestamp, startOfNextDay)
+	if startOfDay.After(timestamp) {
+		c.report("StartOfLocalDay", timestamp, "the start of the local day %s is after the timestamp", startOfDay)
+	}
+	if !startOfNextDay.After(timestamp) {
+		c.report("StartOfNextLocalDay", timestamp, "the start of the next local day %s is not after the timestamp", startOfNextDay)
+	}
+	if !c.calculator.IsLocalMidnight(startOfDay) {
+		c.report("IsLocalMidnight", startOfDay, "the start of a local day is not considered local midnight")
+	}
+	if !c.calculator.IsLocalMidnight(startOfNextDay) {
+		c.report("IsLocalMidnight", startOfNextDay, "the start of the next local day is not considered local midnight")
+	}
+	if !c.calculator.StartOfLocalDay(startOfNextDay.Add(-time.Nanosecond)).Equal(startOfDay) {
+		c.report("StartOfNextLocalDay", timestamp, "the local day does not end right before the start of the next local day %s", startOfNextDay)
+	}
+	// usually only the start of the local day is midnight. But if the local date is repeated (because the clocks are set back across midnight), the next local day may start again right at timestamp
+	if isMidnight := timestamp.Equal(startOfDay) || c.calculator.StartOfNextLocalDay(timestamp.Add(-time.Nanosecond)).Equal(timestamp); c.calculator.IsLocalMidnight(timestamp) != isMidnight {
+		c.report("IsLocalMidnight", timestamp, "the result is %t but the start of the local day is %s", c.calculator.IsLocalMidnight(timestamp), startOfDay)
+	}
+	if c.calculator.GetLocalWeekday(timestamp) != c.calculator.GetLocalWeekday(startOfDay) {
+		c.report("GetLocalWeekday", timestamp, "the weekday differs from the weekday at the start of the local day %s", startOfDay)
+	}
+}
+
+func (c *checker) checkLocalMonth(timestamp time.Time) {
+	startOfMonth := c.calculator.StartOfLocalMonth(timestamp)
+	startOfNextMonth := c.calculator.StartOfNextLocalMonth(timestamp)
+	c.checkUTC("StartOfLocalMonth", timestamp, startOfMonth)
+	c.checkUTC("StartOfNextLocalMonth", timestamp, startOfNextMonth)
+	if startOfMonth.After(timestamp) {
+		c.report("StartOfLocalMonth", timestamp, "the start of the local month %s is after the timestamp", startOfMonth)
+	}
+	if !startOfNextMonth.After(timestamp) {
+		c.report("StartOfNextLocalMonth", timestamp, "the start of the next local month %s is not after the timestamp", startOfNextMonth)
+	}
+	if !c.calculator.StartOfLocalDay(startOfMonth).Equal(startOfMonth) {
+		c.report("StartOfLocalMonth", timestamp, "the start of the local month %s is not the start of a local day", startOfMonth)
+	}
+	if !c.calculator.IsLocalMidnight(startOfNextMonth) {
+		c.report("StartOfNextLocalMonth", timestamp, "the start of the next local month %s is not local midnight", startOfNextMonth)
+	}
+	if !c.calculator.StartOfLocalMonth(startOfNextMonth.Add(-time.Nanosecond)).Equal(startOfMonth) {
+		c.report("StartOfNextLocalMonth", timestamp, "the local month does not end right before the start of the next local month %s", startOfNextMonth)
+	}
+}
+
+func (c *checker) checkLocalWeek(timestamp time.Time) {
+	startOfWeek := c.weeks.StartOfLocalWeek(timestamp)
+	startOfNextWeek := c.weeks.StartOfNextLocalWeek(timestamp)
+	c.checkUTC("StartOfLocalWeek", timestamp, startOfWeek)
+	c.checkUTC("StartOfNextLocalWeek", timestamp, startOfNextWeek)
+	if startOfWeek.After(timestamp) {
+		c.report("StartOfLocalWeek", timestamp, "the start of the local week %s is after the timestamp", startOfWeek)
+	}
+	if !startOfNextWeek.After(timestamp) {
+		c.report("StartOfNextLocalWeek", timestamp, "the start of the next local week %s is not after the timestamp", startOfNextWeek)
+	}
+	if !c.calculator.StartOfLocalDay(startOfWeek).Equal(startOfWeek) {
+		c.report("StartOfLocalWeek", timestamp, "the start of the local week %s is not the start of a local day", startOfWeek)
+	}
+	if !c.weeks.StartOfLocalWeek(startOfNextWeek.Add(-time.Nanosecond)).Equal(startOfWeek) {
+		c.report("StartOfNextLocalWeek", timestamp, "the local week does not end right before the start of the next local week %s", startOfNextWeek)
+	}
+	if !c.weeks.StartOfLocalWeek(startOfNextWeek).Equal(startOfNextWeek) {
+		c.report("StartOfLocalWeek", startOfNextWeek, "the start of the next local week is not the start of its own local week")
+	}
+}
+
+func (c *checker) checkBusinessDay(timestamp time.Time) {
+	if c.businessDays.IsLocalBusinessDay(timestamp) != c.businessDays.IsLocalBusinessDay(c.calculator.StartOfLocalDay(timestamp)) {
+		c.report("IsLocalBusinessDay", timestamp, "the result differs from the result at the start of the local day")
+	}
+	if c.businessDays.IsLocalHoliday(timestamp) && c.businessDays.IsLocalBusinessDay(timestamp) {
+		c.report("IsLocalBusinessDay", timestamp, "a holiday is considered a business day")
+	}
+}
+
+func (c *checker) checkAddLocalDays(timestamp time.Time) {
+	if !c.calculator.AddLocalDays(timestamp, 0).Equal(timestamp) {
+		c.report("AddLocalDays", timestamp, "adding 0 local days changes the timestamp")
+	}
+	plusOneDay := c.calculator.AddLocalDays(timestamp, 1)
+	c.checkUTC("AddLocalDays", timestamp, plusOneDay)
+	if !plusOneDay.After(timestamp) {
+		c.report("AddLocalDays", timestamp, "adding 1 local day results in %s which is not after the timestamp", plusOneDay)
+	}
+	// for arbitrary timestamps the result might be on the day after the next local day, if the local time is skipped on the next local day
+	startOfDay := c.calculator.StartOfLocalDay(timestamp)
+	startOfNextDay := c.calculator.StartOfNextLocalDay(startOfDay)
+	if actual := c.calculator.StartOfLocalDay(c.calculator.AddLocalDays(startOfDay, 1)); !actual.Equal(startOfNextDay) {
+		c.report("AddLocalDays", startOfDay, "adding 1 local day to the start of a local day results in the local day starting at %s instead of %s", actual, startOfNextDay)
+	}
+}
+
+func (c *checker) checkNextLocalWeekday(timestamp time.Time, weekday time.Weekday) {
+	nextWeekday := c.calculator.NextLocalWeekday(timestamp, weekday)
+	c.checkUTC("NextLocalWeekday", timestamp, nextWeekday)
+	if !nextWeekday.After(timestamp) {
+		c.report("NextLocalWeekday", timestamp, "the next %s %s is not after the timestamp", weekday, nextWeekday)
+	}
+	if !c.calculator.IsLocalMidnight(nextWeekday) {
+		c.report("NextLocalWeekday", timestamp, "the next %s %s is not local midnight", weekday, nextWeekday)
+	}
+	if c.calculator.GetLocalWeekday(nextWeekday) != weekday {
+		c.report("NextLocalWeekday", timestamp, "the next %s %s is a %s", weekday, nextWeekday, c.calculator.GetLocalWeekday(nextWeekday))
+	}
+	// the result has to be the first of the following local day starts that has the requested weekday (usually it's one of the next 7 but it might be later if a local date is skipped, like 2011-12-30 in "Pacific/Apia")
+	startOfDay := timestamp
+	for i := 0; i < 14; i++ {
+		startOfDay = c.calculator.StartOfNextLocalDay(startOfDay)
+		if !startOfDay.Before(nextWeekday) {
+			break
+		}
+		if c.calculator.GetLocalWeekday(startOfDay) == weekday {
+			c.report("NextLocalWeekday", timestamp, "the local day starting at %s is an earlier %s than %s", startOfDay, weekday, nextWeekday)
+			return
+		}
+	}
+	if !startOfDay.Equal(nextWeekday) {
+		c.report("NextLocalWeekday", timestamp, "the next %s %s is not the start of one of the following local days", weekday, nextWeekday)
+	}
+}
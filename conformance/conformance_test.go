@@ -0,0 +1,108 @@
+package conformance_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/corbym/gocrest/is"
+	"github.com/corbym/gocrest/then"
+	"github.com/hochfrequenz/go-local-days/conformance"
+	"github.com/hochfrequenz/go-local-days/embedded_tzdata"
+	"github.com/hochfrequenz/go-local-days/germany"
+	"github.com/hochfrequenz/go-local-days/local_days"
+	"github.com/stretchr/testify/suite"
+)
+
+// Test_Germany_Conforms tests that the German calculator conforms for a longer period of time.
+func (s *Suite) Test_Germany_Conforms() {
+	violations := conformance.Check(germany.NewGermanLocalDaysCalculator(), 1990, 2040)
+	then.AssertThat(s.T(), violations, is.Empty())
+}
+
+// exoticZoneNames are zones in which local days are hard to get right. In short mode, only these are checked.
+var exoticZoneNames = []string{
+	"Europe/Berlin",
+	"Europe/Dublin",       // negative daylight saving time (winter time is the exception)
+	"Europe/Moscow",       // permanent changes of the UTC offset in 2011 and 2014
+	"Atlantic/Azores",     // the clocks are set back from 01:00am to midnight, so midnight occurs twice
+	"America/Santiago",    // the clocks are set forward at midnight, so midnight is skipped
+	"America/Sao_Paulo",   // daylight saving time started at midnight until 2019
+	"America/Havana",      // transitions at midnight and 01:00am
+	"America/St_Johns",    // UTC-03:30 and transitions at 00:01 until 2011
+	"America/Caracas",     // half hour changes of the UTC offset in 2007 and 2016
+	"Asia/Tehran",         // UTC+03:30 and transitions at midnight until 2022
+	"Asia/Gaza",           // transitions at midnight and at 01:00am, varying from year to year
+	"Asia/Beirut",         // transitions at midnight
+	"Asia/Pyongyang",      // half hour changes of the UTC offset in 2015 and 2018
+	"Africa/Cairo",        // transitions at midnight
+	"Africa/Casablanca",   // daylight saving time is interrupted during Ramadan
+	"Australia/Lord_Howe", // the clocks change by half an hour
+	"Antarctica/Troll",    // the clocks change by two hours
+	"Antarctica/Casey",    // the clocks change by three hours, across midnight
+	"Pacific/Apia",        // skipped December 30th 2011 by switching sides of the date line
+	"Pacific/Chatham",     // UTC+12:45 and UTC+13:45
+	"Pacific/Kiritimati",  // UTC+14:00
+	"America/Adak",        // UTC-10:00 with daylight saving time
+	"Asia/Kolkata",        // UTC+05:30 without daylight saving time
+}
+
+// Test_All_Zones_Conform tests that the zone based calculator conforms for every zone in the embedded tzdata, which takes a minute or two. In short mode, only the exotic zones are checked.
+func (s *Suite) Test_All_Zones_Conform() {
+	zoneNames := exoticZoneNames
+	if !testing.Short() {
+		var err error
+		zoneNames, err = embedded_tzdata.ZoneNames()
+		then.AssertThat(s.T(), err, is.Nil())
+		then.AssertThat(s.T(), len(zoneNames), is.GreaterThan(400))
+	}
+	fromYear, toYear := 2005, 2025
+	for _, zoneName := range zoneNames {
+		calculator, err := embedded_tzdata.NewTimeZoneBasedLocalTimeConverter(zoneName)
+		if err != nil {
+			s.T().Errorf("%s could not be loaded: %v", zoneName, err)
+			continue
+		}
+		violations := conformance.Check(calculator, fromYear, toYear)
+		if len(violations) > 0 {
+			s.T().Errorf("%s violates %d invariants, e.g. %s", zoneName, len(violations), violations[0].Error())
+		}
+	}
+}
+
+// Test_Violations_Are_Reported tests that a calculator which is off by one hour does not conform.
+func (s *Suite) Test_Violations_Are_Reported() {
+	violations := conformance.Check(offByOneHourCalculator{germany.NewGermanLocalDaysCalculator()}, 2022, 2022)
+	violatedMethods := map[string]bool{}
+	for _, violation := range violations {
+		violatedMethods[violation.Method] = true
+	}
+	then.AssertThat(s.T(), violatedMethods["StartOfLocalDay"], is.True())
+	then.AssertThat(s.T(), violatedMethods["IsLocalMidnight"], is.True())
+}
+
+// offByOneHourCalculator returns local day starts that are one hour too late.
+type offByOneHourCalculator struct {
+	local_days.LocalDaysCalculator
+}
+
+func (o offByOneHourCalculator) StartOfLocalDay(timestamp time.Time) time.Time {
+	return o.LocalDaysCalculator.StartOfLocalDay(timestamp).Add(time.Hour)
+}
+
+// ----------------------------
+// test framework boiler plate
+// ---------------------------
+type Suite struct {
+	suite.Suite
+}
+
+// SetupSuite sets up the tests
+func (s *Suite) SetupSuite() {
+}
+
+func (s *Suite) AfterTest(_, _ string) {
+}
+
+func TestInit(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
@@ -0,0 +1,140 @@
+package contracts
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+	"time"
+
+	"github.com/hochfrequenz/go-local-days/local_days"
+)
+
+// AllocationPeriod is the period to which Allocate assigns the amounts.
+type AllocationPeriod int
+
+const (
+	// PerLocalDay allocates the amount to local days.
+	PerLocalDay AllocationPeriod = iota + 1
+	// PerLocalMonth allocates the amount to local months.
+	PerLocalMonth
+)
+
+// Weighting determines the weight of a period in Allocate.
+type Weighting int
+
+const (
+	// ByDays weighs the periods by their number of local days, e.g. 31 for January.
+	ByDays Weighting = iota + 1
+	// ByHours weighs the periods by their number of hours, e.g. 743 for March in Germany.
+	ByHours
+	// ByQuarterHours weighs the periods by their number of quarter hours, e.g. 92 for the local day on which DST starts in Germany.
+	ByQuarterHours
+)
+
+// Allocation is the part of an amount that is allocated to a period.
+type Allocation struct {
+	// Interval is the period, limited to the interval that was allocated
+	local_days.Interval
+	// Amount is the allocated amount in the same (minor) unit as the total, e.g. cents
+	Amount int64
+}
+
+// Allocate splits total (in a minor unit like cents) pro rata across the local days or months of calculator that overlap interval, so that the amounts always sum to total.
+// Each amount is rounded down to the unit of total and the remainder is distributed one unit at a time to the periods with the largest rounding remainders (the earlier period first if remainders are equal), so the result is deterministic. Negative totals are allocated like positive ones with the opposite sign.
+// With ByDays, interval has to start and end at local day starts. With ByHours and ByQuarterHours, all periods within interval have to consist of whole hours or quarter hours. It returns an error otherwise or if interval is empty.
+func Allocate(calculator local_days.LocalDaysCalculator, interval local_days.Interval, total int64, period AllocationPeriod, weighting Weighting) ([]Allocation, error) {
+	if !interval.End.After(interval.Start) {
+		return nil, fmt.Errorf("The interval from %s to %s is empty", interval.Start, interval.End)
+	}
+	startOfPeriod, startOfNextPeriod := calculator.StartOfLocalDay, calculator.StartOfNextLocalDay
+	switch period {
+	case PerLocalDay:
+	case PerLocalMonth:
+		startOfPeriod, startOfNextPeriod = calculator.StartOfLocalMonth, calculator.StartOfNextLocalMonth
+	default:
+		return nil, fmt.Errorf("The allocation period %d is invalid", period)
+	}
+	var allocations []Allocation
+	var weights []uint64
+	for start := startOfPeriod(interval.Start); start.Before(interval.End); start = startOfNextPeriod(start) {
+		part := local_days.Interval{Start: start, End: startOfNextPeriod(start)}
+		if part.Start.Before(interval.Start) {
+			part.Start = interval.Start
+		}
+		if part.End.After(interval.End) {
+			part.End = interval.End
+		}
+		weight, err := weightOf(calculator, part, weighting)
+		if err != nil {
+			return nil, err
+		}
+		allocations = append(allocations, Allocation{Interval: part})
+		weights = append(weights, weight)
+	}
+	amounts := allocateLargestRemainder(absolute(total), weights)
+	for i := range allocations {
+		allocations[i].Amount = int64(amounts[i])
+		if total < 0 {
+			allocations[i].Amount = -allocations[i].Amount
+		}
+	}
+	return allocations, nil
+}
+
+func weightOf(calculator local_days.LocalDaysCalculator, part local_days.Interval, weighting Weighting) (uint64, error) {
+	var unit time.Duration
+	switch weighting {
+	case ByDays:
+		if !calculator.IsLocalMidnight(part.Start) || !calculator.IsLocalMidnight(part.End) {
+			return 0, fmt.Errorf("The period from %s to %s does not consist of whole local days", part.Start, part.End)
+		}
+		days := uint64(0)
+		for start := part.Start; start.Before(part.End); start = calculator.StartOfNextLocalDay(start) {
+			days++
+		}
+		return days, nil
+	case ByHours:
+		unit = time.Hour
+	case ByQuarterHours:
+		unit = 15 * time.Minute
+	default:
+		return 0, fmt.Errorf("The weighting %d is invalid", weighting)
+	}
+	if part.Duration()%unit != 0 {
+		return 0, fmt.Errorf("The period from %s to %s does not consist of whole %s units", part.Start, part.End, unit)
+	}
+	return uint64(part.Duration() / unit), nil
+}
+
+// allocateLargestRemainder splits total proportional to weights using the largest remainder method.
+func allocateLargestRemainder(total uint64, weights []uint64) []uint64 {
+	var sum uint64
+	for _, weight := range weights {
+		sum += weight
+	}
+	amounts := make([]uint64, len(weights))
+	remainders := make([]uint64, len(weights))
+	var allocated uint64
+	for i, weight := range weights {
+		// total*weight/sum does not fit into 64 bits for large totals, so we calculate it with 128 bits
+		high, low := bits.Mul64(total, weight)
+		amounts[i], remainders[i] = bits.Div64(high, low, sum)
+		allocated += amounts[i]
+	}
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return remainders[order[i]] > remainders[order[j]] })
+	for i := uint64(0); i < total-allocated; i++ {
+		amounts[order[i]]++
+	}
+	return amounts
+}
+
+func absolute(amount int64) uint64 {
+	if amount < 0 {
+		return uint64(-amount)
+	}
+	return uint64(amount)
+}
@@ -0,0 +1,226 @@
+// Package contracts calculates the terms, renewals and notice periods of (energy supply) contracts in local days.
+// All dates are expressed as the UTC start of a local day of a local_days.LocalDaysCalculator.
+package contracts
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hochfrequenz/go-local-days/local_days"
+)
+
+// Period is a duration in calendar months and local days, e.g. the minimum term or the notice period of a contract.
+type Period struct {
+	Months int
+	Days   int
+}
+
+// Months returns a Period of n months.
+func Months(n int) Period {
+	return Period{Months: n}
+}
+
+// Years returns a Period of n years (12n months).
+func Years(n int) Period {
+	return Period{Months: 12 * n}
+}
+
+// Weeks returns a Period of n weeks (7n days).
+func Weeks(n int) Period {
+	return Period{Days: 7 * n}
+}
+
+// Days returns a Period of n days.
+func Days(n int) Period {
+	return Period{Days: n}
+}
+
+// IsZero returns true if and only if the period has neither months nor days.
+func (p Period) IsZero() bool {
+	return p.Months == 0 && p.Days == 0
+}
+
+func (p Period) String() string {
+	return fmt.Sprintf("%d months and %d days", p.Months, p.Days)
+}
+
+// addTo returns the local date (as midnight UTC) at which a period starting at date ends (exclusive). A period of months that would end on a day that does not exist in the month (e.g. one month from 31 January) ends on the first day of the following month (BGB §188 (3)).
+func (p Period) addTo(date time.Time) time.Time {
+	year, month, day := date.Date()
+	target := time.Date(year, month+time.Month(p.Months), 1, 0, 0, 0, 0, time.UTC)
+	if day <= daysIn(target.Year(), target.Month()) {
+		target = target.AddDate(0, 0, day-1)
+	} else {
+		target = target.AddDate(0, 1, 0)
+	}
+	return target.AddDate(0, 0, p.Days)
+}
+
+// subtractFrom returns the local date (as midnight UTC) at which a period ending at date (exclusive) starts. If the day does not exist in the month, the period starts on the first day of the following month.
+func (p Period) subtractFrom(date time.Time) time.Time {
+	year, month, day := date.AddDate(0, 0, -p.Days).Date()
+	target := time.Date(year, month-time.Month(p.Months), 1, 0, 0, 0, 0, time.UTC)
+	if day <= daysIn(target.Year(), target.Month()) {
+		return target.AddDate(0, 0, day-1)
+	}
+	return target.AddDate(0, 1, 0)
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// EndAnchor restricts the end dates of open-ended contracts.
+type EndAnchor int
+
+const (
+	// ToAnyDay allows the contract to end at the end of any local day.
+	ToAnyDay EndAnchor = iota
+	// ToEndOfMonth allows the contract to end at the end of a local month only.
+	ToEndOfMonth
+	// ToEndOfQuarter allows the contract to end at the end of a quarter only (31 March, 30 June, 30 September or 31 December).
+	ToEndOfQuarter
+	// ToEndOfYear allows the contract to end at the end of a year only.
+	ToEndOfYear
+)
+
+func (a EndAnchor) String() string {
+	switch a {
+	case ToAnyDay:
+		return "any day"
+	case ToEndOfMonth:
+		return "end of month"
+	case ToEndOfQuarter:
+		return "end of quarter"
+	case ToEndOfYear:
+		return "end of year"
+	}
+	return "unknown"
+}
+
+// atOrAfter returns the first local date (as midnight UTC) at or after date on which a contract may end (exclusive), e.g. the first day of a month for ToEndOfMonth.
+func (a EndAnchor) atOrAfter(date time.Time) time.Time {
+	year, month, day := date.Date()
+	switch a {
+	case ToEndOfMonth:
+		if day != 1 {
+			return time.Date(year, month+1, 1, 0, 0, 0, 0, time.UTC)
+		}
+	case ToEndOfQuarter:
+		if day != 1 || (month-1)%3 != 0 {
+			return time.Date(year, month-(month-1)%3+3, 1, 0, 0, 0, 0, time.UTC)
+		}
+	case ToEndOfYear:
+		if day != 1 || month != time.January {
+			return time.Date(year+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+		}
+	}
+	return date
+}
+
+// Terms are the term rules of a contract, e.g. "12 months, then monthly, 1 month notice" is Terms{MinimumTerm: Months(12), Renewal: Months(1), NoticePeriod: Months(1)}
+// and "12 months, then open-ended, 1 month notice to the end of a month" is Terms{MinimumTerm: Months(12), NoticePeriod: Months(1), EndsAt: ToEndOfMonth}.
+type Terms struct {
+	// MinimumTerm is the period from the start of the contract until the earliest possible end
+	MinimumTerm Period
+	// Renewal is the period by which the contract is renewed automatically if no notice is given in time. A zero renewal means that the contract is open-ended after the minimum term and can end at every EndsAt anchor.
+	Renewal Period
+	// NoticePeriod is the period between the last day on which notice can be received and the end of the contract
+	NoticePeriod Period
+	// EndsAt restricts the end dates of open-ended contracts (it's ignored if the contract is renewed automatically)
+	EndsAt EndAnchor
+}
+
+// Validate returns an error if the terms are invalid, e.g. because the minimum term is not positive.
+func (t Terms) Validate() error {
+	for _, period := range []struct {
+		name  string
+		value Period
+	}{{"minimum term", t.MinimumTerm}, {"renewal", t.Renewal}, {"notice period", t.NoticePeriod}} {
+		if period.value.Months < 0 || period.value.Days < 0 {
+			return fmt.Errorf("The %s %s is negative", period.name, period.value)
+		}
+	}
+	if t.MinimumTerm.IsZero() {
+		return fmt.Errorf("The minimum term must not be zero")
+	}
+	if t.EndsAt < ToAnyDay || t.EndsAt > ToEndOfYear {
+		return fmt.Errorf("The end anchor %d is invalid", t.EndsAt)
+	}
+	return nil
+}
+
+// Termination is the result of a notice.
+type Termination struct {
+	// End is the end of the contract (exclusive), i.e. the start of the first local day that's not covered by the contract anymore
+	End time.Time
+	// LastNoticeDay is the start of the last local day on which the notice has to be received to end the contract at End
+	LastNoticeDay time.Time
+}
+
+// Contract is a contract with Terms that starts at a local day. Create it with NewContract.
+type Contract struct {
+	calculator local_days.LocalDaysCalculator
+	terms      Terms
+	// start is the start of the first local day of the contract and startDate its local date
+	start     time.Time
+	startDate time.Time
+}
+
+// NewContract returns the contract that starts with the local day of start (of calculator) and has the given terms.
+// It returns an error if the terms are invalid or if the calculator does not know the local dates of its local days (see local_days.LocalDate).
+func NewContract(calculator local_days.LocalDaysCalculator, start time.Time, terms Terms) (Contract, error) {
+	if err := terms.Validate(); err != nil {
+		return Contract{}, err
+	}
+	startDate, err := local_days.LocalDate(calculator, start)
+	if err != nil {
+		return Contract{}, err
+	}
+	return Contract{calculator: calculator, terms: terms, start: calculator.StartOfLocalDay(start), startDate: startDate}, nil
+}
+
+// Start returns the start of the first local day of the contract.
+func (c Contract) Start() time.Time {
+	return c.start
+}
+
+// EndOfMinimumTerm returns the end of the minimum term (exclusive), i.e. the start of the first local day after the minimum term.
+func (c Contract) EndOfMinimumTerm() time.Time {
+	return c.startOfLocalDate(c.terms.MinimumTerm.addTo(c.startDate))
+}
+
+// EarliestTermination returns the earliest possible end of the contract and the last day on which notice can be given for it, if notice is received on the local day of noticeReceived.
+// A notice that is received before the contract starts is treated like a notice on the first day of the contract.
+func (c Contract) EarliestTermination(noticeReceived time.Time) Termination {
+	noticeDate, _ := local_days.LocalDate(c.calculator, noticeReceived)
+	if noticeDate.Before(c.startDate) {
+		noticeDate = c.startDate
+	}
+	end := c.terms.MinimumTerm.addTo(c.startDate)
+	if c.lastNoticeDate(end).Before(noticeDate) {
+		if c.terms.Renewal.IsZero() {
+			// the earliest end of the open-ended contract is the first anchor at or after the notice period from the day after the notice
+			end = c.terms.EndsAt.atOrAfter(c.terms.NoticePeriod.addTo(noticeDate.AddDate(0, 0, 1)))
+			for c.lastNoticeDate(end).Before(noticeDate) {
+				end = c.terms.EndsAt.atOrAfter(end.AddDate(0, 0, 1))
+			}
+		} else {
+			for c.lastNoticeDate(end).Before(noticeDate) {
+				end = c.terms.Renewal.addTo(end)
+			}
+		}
+	}
+	return Termination{End: c.startOfLocalDate(end), LastNoticeDay: c.startOfLocalDate(c.lastNoticeDate(end))}
+}
+
+// lastNoticeDate returns the local date of the last day on which notice can be received for a contract end (exclusive) at the local date end.
+func (c Contract) lastNoticeDate(end time.Time) time.Time {
+	return c.terms.NoticePeriod.subtractFrom(end).AddDate(0, 0, -1)
+}
+
+// startOfLocalDate returns the start of the local day with the local date (as midnight UTC).
+func (c Contract) startOfLocalDate(date time.Time) time.Time {
+	days := int(date.Sub(c.startDate) / (24 * time.Hour))
+	return c.calculator.StartOfLocalDay(c.calculator.AddLocalDays(c.start, days))
+}
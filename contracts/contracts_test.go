@@ -0,0 +1,241 @@
+package contracts_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/corbym/gocrest/is"
+	"github.com/corbym/gocrest/then"
+	"github.com/hochfrequenz/go-local-days/contracts"
+	"github.com/hochfrequenz/go-local-days/germany"
+	"github.com/hochfrequenz/go-local-days/local_days"
+	"github.com/stretchr/testify/suite"
+)
+
+func (s *Suite) newContract(start time.Time, terms contracts.Terms) contracts.Contract {
+	contract, err := contracts.NewContract(germany.NewGermanLocalDaysCalculator(), start, terms)
+	then.AssertThat(s.T(), err, is.Nil())
+	return contract
+}
+
+/***************
+ Periods
+***************/
+
+// Test_End_Of_Minimum_Term tests that the minimum term ends at the start of the local day with the same number in the target month.
+func (s *Suite) Test_End_Of_Minimum_Term() {
+	contract := s.newContract(time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC), contracts.Terms{MinimumTerm: contracts.Years(1)})
+	then.AssertThat(s.T(), contract.Start(), is.EqualTo(time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), contract.EndOfMinimumTerm(), is.EqualTo(time.Date(2023, 12, 31, 23, 0, 0, 0, time.UTC)))
+	// one month from 31 January ends at the end of February
+	contract = s.newContract(time.Date(2023, 1, 31, 12, 0, 0, 0, time.UTC), contracts.Terms{MinimumTerm: contracts.Months(1)})
+	then.AssertThat(s.T(), contract.EndOfMinimumTerm(), is.EqualTo(time.Date(2023, 2, 28, 23, 0, 0, 0, time.UTC)))
+	// the minimum term ends in CEST
+	contract = s.newContract(time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC), contracts.Terms{MinimumTerm: contracts.Weeks(5)})
+	then.AssertThat(s.T(), contract.EndOfMinimumTerm(), is.EqualTo(time.Date(2023, 4, 4, 22, 0, 0, 0, time.UTC)))
+}
+
+/***************
+ Terminations
+***************/
+
+// Test_Termination_With_Renewals tests "12 months, then monthly, 1 month notice".
+func (s *Suite) Test_Termination_With_Renewals() {
+	contract := s.newContract(time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC), contracts.Terms{MinimumTerm: contracts.Months(12), Renewal: contracts.Months(1), NoticePeriod: contracts.Months(1)})
+	endOfMinimumTerm := contracts.Termination{End: time.Date(2023, 12, 31, 23, 0, 0, 0, time.UTC), LastNoticeDay: time.Date(2023, 11, 29, 23, 0, 0, 0, time.UTC)}
+	then.AssertThat(s.T(), contract.EarliestTermination(time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)), is.EqualTo(endOfMinimumTerm))
+	then.AssertThat(s.T(), contract.EarliestTermination(time.Date(2023, 11, 30, 22, 59, 0, 0, time.UTC)), is.EqualTo(endOfMinimumTerm))
+	// notice before the start of the contract
+	then.AssertThat(s.T(), contract.EarliestTermination(time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)), is.EqualTo(endOfMinimumTerm))
+	// 2023-11-30 23:00 UTC is already 1 December in Germany
+	then.AssertThat(s.T(), contract.EarliestTermination(time.Date(2023, 11, 30, 23, 0, 0, 0, time.UTC)), is.EqualTo(contracts.Termination{End: time.Date(2024, 1, 31, 23, 0, 0, 0, time.UTC), LastNoticeDay: time.Date(2023, 12, 30, 23, 0, 0, 0, time.UTC)}))
+	then.AssertThat(s.T(), contract.EarliestTermination(time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC)), is.EqualTo(contracts.Termination{End: time.Date(2024, 3, 31, 22, 0, 0, 0, time.UTC), LastNoticeDay: time.Date(2024, 2, 28, 23, 0, 0, 0, time.UTC)}))
+}
+
+// Test_Termination_Of_Open_Ended_Contract tests "12 months, then open-ended, 1 month notice to the end of a month".
+func (s *Suite) Test_Termination_Of_Open_Ended_Contract() {
+	contract := s.newContract(time.Date(2023, 3, 15, 12, 0, 0, 0, time.UTC), contracts.Terms{MinimumTerm: contracts.Months(12), NoticePeriod: contracts.Months(1), EndsAt: contracts.ToEndOfMonth})
+	// the end of the minimum term is possible even if it's not the end of a month
+	then.AssertThat(s.T(), contract.EarliestTermination(time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)), is.EqualTo(contracts.Termination{End: time.Date(2024, 3, 14, 23, 0, 0, 0, time.UTC), LastNoticeDay: time.Date(2024, 2, 13, 23, 0, 0, 0, time.UTC)}))
+	then.AssertThat(s.T(), contract.EarliestTermination(time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)), is.EqualTo(contracts.Termination{End: time.Date(2024, 4, 30, 22, 0, 0, 0, time.UTC), LastNoticeDay: time.Date(2024, 3, 30, 23, 0, 0, 0, time.UTC)}))
+	then.AssertThat(s.T(), contract.EarliestTermination(time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC)), is.EqualTo(contracts.Termination{End: time.Date(2024, 4, 30, 22, 0, 0, 0, time.UTC), LastNoticeDay: time.Date(2024, 3, 30, 23, 0, 0, 0, time.UTC)}))
+}
+
+// Test_Termination_To_End_Of_Quarter tests an open-ended contract with 3 months notice to the end of a quarter.
+func (s *Suite) Test_Termination_To_End_Of_Quarter() {
+	contract := s.newContract(time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC), contracts.Terms{MinimumTerm: contracts.Months(12), NoticePeriod: contracts.Months(3), EndsAt: contracts.ToEndOfQuarter})
+	then.AssertThat(s.T(), contract.EarliestTermination(time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)), is.EqualTo(contracts.Termination{End: time.Date(2024, 6, 30, 22, 0, 0, 0, time.UTC), LastNoticeDay: time.Date(2024, 3, 30, 23, 0, 0, 0, time.UTC)}))
+}
+
+// Test_Invalid_Terms tests that invalid terms are rejected.
+func (s *Suite) Test_Invalid_Terms() {
+	for _, terms := range []contracts.Terms{
+		{},
+		{MinimumTerm: contracts.Months(12), NoticePeriod: contracts.Days(-1)},
+		{MinimumTerm: contracts.Months(12), EndsAt: contracts.EndAnchor(7)},
+	} {
+		_, err := contracts.NewContract(germany.NewGermanLocalDaysCalculator(), time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), terms)
+		then.AssertThat(s.T(), err, is.Not(is.Nil()))
+	}
+}
+
+/***************
+ Installment Plans
+***************/
+
+func (s *Suite) germanCalculatorWithHolidays() local_days.LocalDaysCalculator {
+	calculator, err := local_days.CalculatorSpec{ZoneName: "Europe/Berlin", HolidayRegion: germany.NationalHolidaysRegion}.Build()
+	then.AssertThat(s.T(), err, is.Nil())
+	return calculator
+}
+
+// Test_Monthly_Installments_Following_Business_Day tests that due dates on weekends and holidays are shifted to the next business day and omitted if that's after the billing period.
+func (s *Suite) Test_Monthly_Installments_Following_Business_Day() {
+	plan := contracts.InstallmentPlan{Frequency: contracts.Monthly, AnchorDay: 31, Shift: contracts.FollowingBusinessDay}
+	dueDates, err := plan.DueDates(s.germanCalculatorWithHolidays(), time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC), time.Date(2023, 12, 31, 23, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), dueDates, is.EqualTo([]time.Time{
+		time.Date(2023, 1, 30, 23, 0, 0, 0, time.UTC),
+		time.Date(2023, 2, 27, 23, 0, 0, 0, time.UTC), // clamped to the end of February
+		time.Date(2023, 3, 30, 22, 0, 0, 0, time.UTC),
+		time.Date(2023, 5, 1, 22, 0, 0, 0, time.UTC), // 30 April is a Sunday and 1 May a holiday
+		time.Date(2023, 5, 30, 22, 0, 0, 0, time.UTC),
+		time.Date(2023, 6, 29, 22, 0, 0, 0, time.UTC),
+		time.Date(2023, 7, 30, 22, 0, 0, 0, time.UTC),
+		time.Date(2023, 8, 30, 22, 0, 0, 0, time.UTC),
+		time.Date(2023, 10, 1, 22, 0, 0, 0, time.UTC), // 30 September is a Saturday
+		time.Date(2023, 10, 30, 23, 0, 0, 0, time.UTC),
+		time.Date(2023, 11, 29, 23, 0, 0, 0, time.UTC),
+		// 31 December is a Sunday and 1 January a holiday, so the last installment is due after the billing period
+	}))
+}
+
+// Test_Monthly_Installments_Modified_Following_Business_Day tests that due dates are shifted back if the next business day is in the next month.
+func (s *Suite) Test_Monthly_Installments_Modified_Following_Business_Day() {
+	plan := contracts.InstallmentPlan{Frequency: contracts.Monthly, AnchorDay: 31, Shift: contracts.ModifiedFollowingBusinessDay}
+	dueDates, err := plan.DueDates(s.germanCalculatorWithHolidays(), time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC), time.Date(2023, 12, 31, 23, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), len(dueDates), is.EqualTo(12))
+	then.AssertThat(s.T(), dueDates[3], is.EqualTo(time.Date(2023, 4, 27, 22, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), dueDates[8], is.EqualTo(time.Date(2023, 9, 28, 22, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), dueDates[11], is.EqualTo(time.Date(2023, 12, 28, 23, 0, 0, 0, time.UTC)))
+}
+
+// Test_Quarterly_Installments tests that the installments are due every third month starting with the month of the start of the billing period.
+func (s *Suite) Test_Quarterly_Installments() {
+	plan := contracts.InstallmentPlan{Frequency: contracts.Quarterly, AnchorDay: 15}
+	dueDates, err := plan.DueDates(germany.NewGermanLocalDaysCalculator(), time.Date(2023, 2, 10, 12, 0, 0, 0, time.UTC), time.Date(2024, 2, 10, 12, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), dueDates, is.EqualTo([]time.Time{
+		time.Date(2023, 2, 14, 23, 0, 0, 0, time.UTC),
+		time.Date(2023, 5, 14, 22, 0, 0, 0, time.UTC),
+		time.Date(2023, 8, 14, 22, 0, 0, 0, time.UTC),
+		time.Date(2023, 11, 14, 23, 0, 0, 0, time.UTC),
+	}))
+	// the due date in the month of the start is omitted if it's before the start
+	dueDates, err = plan.DueDates(germany.NewGermanLocalDaysCalculator(), time.Date(2023, 2, 20, 12, 0, 0, 0, time.UTC), time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), dueDates, is.EqualTo([]time.Time{time.Date(2023, 5, 14, 22, 0, 0, 0, time.UTC)}))
+}
+
+// Test_Invalid_Installment_Plans tests that invalid plans are rejected.
+func (s *Suite) Test_Invalid_Installment_Plans() {
+	for _, plan := range []contracts.InstallmentPlan{
+		{AnchorDay: 1},
+		{Frequency: contracts.Monthly, AnchorDay: 0},
+		{Frequency: contracts.Monthly, AnchorDay: 32},
+		{Frequency: contracts.Monthly, AnchorDay: 1, Shift: contracts.BusinessDayShift(9)},
+	} {
+		_, err := plan.DueDates(germany.NewGermanLocalDaysCalculator(), time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		then.AssertThat(s.T(), err, is.Not(is.Nil()))
+	}
+}
+
+/***************
+ Pro-Rata Allocation
+***************/
+
+func (s *Suite) allocate(interval local_days.Interval, total int64, period contracts.AllocationPeriod, weighting contracts.Weighting) []int64 {
+	allocations, err := contracts.Allocate(germany.NewGermanLocalDaysCalculator(), interval, total, period, weighting)
+	then.AssertThat(s.T(), err, is.Nil())
+	var amounts []int64
+	sum := int64(0)
+	for _, allocation := range allocations {
+		amounts = append(amounts, allocation.Amount)
+		sum += allocation.Amount
+	}
+	then.AssertThat(s.T(), sum, is.EqualTo(total))
+	return amounts
+}
+
+// Test_Allocate_Per_Day_By_Days tests an even split of an annual amount.
+func (s *Suite) Test_Allocate_Per_Day_By_Days() {
+	year2023 := local_days.Interval{Start: time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC), End: time.Date(2023, 12, 31, 23, 0, 0, 0, time.UTC)}
+	amounts := s.allocate(year2023, 36500, contracts.PerLocalDay, contracts.ByDays)
+	then.AssertThat(s.T(), len(amounts), is.EqualTo(365))
+	for _, amount := range amounts {
+		then.AssertThat(s.T(), amount, is.EqualTo(int64(100)))
+	}
+}
+
+// Test_Allocate_Per_Month_By_Hours tests that the remainder goes to the months with the largest rounding remainders.
+func (s *Suite) Test_Allocate_Per_Month_By_Hours() {
+	year2023 := local_days.Interval{Start: time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC), End: time.Date(2023, 12, 31, 23, 0, 0, 0, time.UTC)}
+	amounts := s.allocate(year2023, 100000, contracts.PerLocalMonth, contracts.ByHours)
+	// 100000 * 743 / 8760 = 8481.73 (March), 100000 * 745 / 8760 = 8504.57 (October) and 100000 * 672 / 8760 = 7671.23 (February) get the 3 remaining cents
+	then.AssertThat(s.T(), amounts, is.EqualTo([]int64{8493, 7672, 8482, 8219, 8493, 8219, 8493, 8493, 8219, 8505, 8219, 8493}))
+}
+
+// Test_Allocate_Remainder_Deterministically tests that equal remainders go to the earlier periods, also for negative amounts.
+func (s *Suite) Test_Allocate_Remainder_Deterministically() {
+	threeDays := local_days.Interval{Start: time.Date(2023, 1, 1, 23, 0, 0, 0, time.UTC), End: time.Date(2023, 1, 4, 23, 0, 0, 0, time.UTC)}
+	then.AssertThat(s.T(), s.allocate(threeDays, 100, contracts.PerLocalDay, contracts.ByDays), is.EqualTo([]int64{34, 33, 33}))
+	then.AssertThat(s.T(), s.allocate(threeDays, -100, contracts.PerLocalDay, contracts.ByDays), is.EqualTo([]int64{-34, -33, -33}))
+	then.AssertThat(s.T(), s.allocate(threeDays, 2, contracts.PerLocalMonth, contracts.ByDays), is.EqualTo([]int64{2}))
+}
+
+// Test_Allocate_By_Quarter_Hours tests the weights of the local day on which DST starts and of a partial local day.
+func (s *Suite) Test_Allocate_By_Quarter_Hours() {
+	twoDays := local_days.Interval{Start: time.Date(2022, 3, 26, 23, 0, 0, 0, time.UTC), End: time.Date(2022, 3, 28, 22, 0, 0, 0, time.UTC)}
+	then.AssertThat(s.T(), s.allocate(twoDays, 1880, contracts.PerLocalDay, contracts.ByQuarterHours), is.EqualTo([]int64{920, 960}))
+	halfDays := local_days.Interval{Start: time.Date(2022, 3, 27, 10, 0, 0, 0, time.UTC), End: time.Date(2022, 3, 28, 10, 0, 0, 0, time.UTC)}
+	allocations, err := contracts.Allocate(germany.NewGermanLocalDaysCalculator(), halfDays, 2400, contracts.PerLocalDay, contracts.ByHours)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), allocations, is.EqualTo([]contracts.Allocation{
+		{Interval: local_days.Interval{Start: time.Date(2022, 3, 27, 10, 0, 0, 0, time.UTC), End: time.Date(2022, 3, 27, 22, 0, 0, 0, time.UTC)}, Amount: 1200},
+		{Interval: local_days.Interval{Start: time.Date(2022, 3, 27, 22, 0, 0, 0, time.UTC), End: time.Date(2022, 3, 28, 10, 0, 0, 0, time.UTC)}, Amount: 1200},
+	}))
+}
+
+// Test_Allocate_Invalid_Arguments tests that intervals that cannot be weighted are rejected.
+func (s *Suite) Test_Allocate_Invalid_Arguments() {
+	calculator := germany.NewGermanLocalDaysCalculator()
+	notAtLocalMidnight := local_days.Interval{Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2023, 1, 2, 23, 0, 0, 0, time.UTC)}
+	_, err := contracts.Allocate(calculator, notAtLocalMidnight, 100, contracts.PerLocalDay, contracts.ByDays)
+	then.AssertThat(s.T(), err, is.Not(is.Nil()))
+	notWholeHours := local_days.Interval{Start: time.Date(2023, 1, 1, 0, 30, 0, 0, time.UTC), End: time.Date(2023, 1, 2, 23, 0, 0, 0, time.UTC)}
+	_, err = contracts.Allocate(calculator, notWholeHours, 100, contracts.PerLocalDay, contracts.ByHours)
+	then.AssertThat(s.T(), err, is.Not(is.Nil()))
+	_, err = contracts.Allocate(calculator, local_days.Interval{Start: notWholeHours.End, End: notWholeHours.End}, 100, contracts.PerLocalDay, contracts.ByHours)
+	then.AssertThat(s.T(), err, is.Not(is.Nil()))
+	_, err = contracts.Allocate(calculator, notAtLocalMidnight, 100, contracts.AllocationPeriod(0), contracts.ByHours)
+	then.AssertThat(s.T(), err, is.Not(is.Nil()))
+}
+
+// ----------------------------
+// test framework boiler plate
+// ---------------------------
+type Suite struct {
+	suite.Suite
+}
+
+// SetupSuite sets up the tests
+func (s *Suite) SetupSuite() {
+}
+
+func (s *Suite) AfterTest(_, _ string) {
+}
+
+func TestInit(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
@@ -0,0 +1,150 @@
+package contracts
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hochfrequenz/go-local-days/local_days"
+)
+
+// InstallmentFrequency is the number of local months between two installments.
+type InstallmentFrequency int
+
+const (
+	// Monthly installments are due every local month.
+	Monthly InstallmentFrequency = 1
+	// Bimonthly installments are due every second local month.
+	Bimonthly InstallmentFrequency = 2
+	// Quarterly installments are due every third local month.
+	Quarterly InstallmentFrequency = 3
+)
+
+// BusinessDayShift determines how due dates that are not local business days (see local_days.BusinessDaysCalculator.IsLocalBusinessDay) are shifted.
+type BusinessDayShift int
+
+const (
+	// NoShift keeps due dates that are not business days.
+	NoShift BusinessDayShift = iota
+	// FollowingBusinessDay shifts due dates to the next business day.
+	FollowingBusinessDay
+	// PrecedingBusinessDay shifts due dates to the previous business day.
+	PrecedingBusinessDay
+	// ModifiedFollowingBusinessDay shifts due dates to the next business day unless it's in the next local month, then to the previous business day.
+	ModifiedFollowingBusinessDay
+)
+
+func (s BusinessDayShift) String() string {
+	switch s {
+	case NoShift:
+		return "no shift"
+	case FollowingBusinessDay:
+		return "following business day"
+	case PrecedingBusinessDay:
+		return "preceding business day"
+	case ModifiedFollowingBusinessDay:
+		return "modified following business day"
+	}
+	return "unknown"
+}
+
+// InstallmentPlan describes the due dates of advance payments (Abschlagsplan), e.g. InstallmentPlan{Frequency: Monthly, AnchorDay: 15, Shift: FollowingBusinessDay} for "monthly on the 15th or the next business day".
+type InstallmentPlan struct {
+	// Frequency is the number of local months between two installments, e.g. Monthly
+	Frequency InstallmentFrequency
+	// AnchorDay is the day of month (1-31) on which the installments are due. It's clamped to the last day of shorter months, e.g. 31 is the 28th or 29th in February.
+	AnchorDay int
+	// Shift determines how due dates that are not business days are shifted
+	Shift BusinessDayShift
+}
+
+// Validate returns an error if the plan is invalid, e.g. because the anchor day is not from 1 to 31.
+func (p InstallmentPlan) Validate() error {
+	if p.Frequency < 1 {
+		return fmt.Errorf("The frequency %d is less than 1 month", p.Frequency)
+	}
+	if p.AnchorDay < 1 || p.AnchorDay > 31 {
+		return fmt.Errorf("The anchor day %d is not from 1 to 31", p.AnchorDay)
+	}
+	if p.Shift < NoShift || p.Shift > ModifiedFollowingBusinessDay {
+		return fmt.Errorf("The business day shift %d is invalid", p.Shift)
+	}
+	return nil
+}
+
+// DueDates returns the starts of the local days on which installments are due within the billing period [start, end), in chronological order.
+// The installments are due in the local month of start and every Frequency months after that; due dates that are before start or not before end (after the shift) are omitted.
+// The business days are those of calculator (all days but Saturdays and Sundays if it does not implement local_days.BusinessDaysCalculator), so use a calculator with holidays (e.g. local_days.CalculatorSpec{ZoneName: "Europe/Berlin", HolidayRegion: "DE"}) to shift due dates on holidays.
+// It returns an error if the plan is invalid or if the calculator does not know the local dates of its local days (see local_days.LocalDate).
+func (p InstallmentPlan) DueDates(calculator local_days.LocalDaysCalculator, start time.Time, end time.Time) ([]time.Time, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := local_days.LocalDate(calculator, start); err != nil {
+		return nil, err
+	}
+	start, end = calculator.StartOfLocalDay(start), end.UTC()
+	var dueDates []time.Time
+	for startOfMonth := calculator.StartOfLocalMonth(start); startOfMonth.Before(end); startOfMonth = addLocalMonths(calculator, startOfMonth, int(p.Frequency)) {
+		dueDate := p.shift(calculator, p.anchorDayOf(calculator, startOfMonth))
+		if !dueDate.Before(start) && dueDate.Before(end) {
+			dueDates = append(dueDates, dueDate)
+		}
+	}
+	return dueDates, nil
+}
+
+// anchorDayOf returns the start of the local day of the anchor day in the local month that starts at startOfMonth.
+func (p InstallmentPlan) anchorDayOf(calculator local_days.LocalDaysCalculator, startOfMonth time.Time) time.Time {
+	lastDate, _ := local_days.LocalDate(calculator, calculator.StartOfNextLocalMonth(startOfMonth).Add(-time.Nanosecond))
+	day := p.AnchorDay
+	if day > lastDate.Day() {
+		day = lastDate.Day()
+	}
+	return calculator.StartOfLocalDay(calculator.AddLocalDays(startOfMonth, day-1))
+}
+
+func (p InstallmentPlan) shift(calculator local_days.LocalDaysCalculator, dueDate time.Time) time.Time {
+	switch p.Shift {
+	case FollowingBusinessDay:
+		return followingBusinessDay(calculator, dueDate)
+	case PrecedingBusinessDay:
+		return precedingBusinessDay(calculator, dueDate)
+	case ModifiedFollowingBusinessDay:
+		if following := followingBusinessDay(calculator, dueDate); following.Before(calculator.StartOfNextLocalMonth(dueDate)) {
+			return following
+		}
+		return precedingBusinessDay(calculator, dueDate)
+	}
+	return dueDate
+}
+
+func followingBusinessDay(calculator local_days.LocalDaysCalculator, startOfDay time.Time) time.Time {
+	for !isBusinessDay(calculator, startOfDay) {
+		startOfDay = calculator.StartOfNextLocalDay(startOfDay)
+	}
+	return startOfDay
+}
+
+func precedingBusinessDay(calculator local_days.LocalDaysCalculator, startOfDay time.Time) time.Time {
+	for !isBusinessDay(calculator, startOfDay) {
+		startOfDay = calculator.StartOfLocalDay(startOfDay.Add(-time.Nanosecond))
+	}
+	return startOfDay
+}
+
+// isBusinessDay returns true if the local day that starts at startOfDay is a business day of calculator. Calculators that don't implement local_days.BusinessDaysCalculator have no holidays.
+func isBusinessDay(calculator local_days.LocalDaysCalculator, startOfDay time.Time) bool {
+	if businessDays, ok := calculator.(local_days.BusinessDaysCalculator); ok {
+		return businessDays.IsLocalBusinessDay(startOfDay)
+	}
+	weekday := calculator.GetLocalWeekday(startOfDay)
+	return weekday != time.Saturday && weekday != time.Sunday
+}
+
+// addLocalMonths returns the start of the local month that starts months local months after startOfMonth.
+func addLocalMonths(calculator local_days.LocalDaysCalculator, startOfMonth time.Time, months int) time.Time {
+	for i := 0; i < months; i++ {
+		startOfMonth = calculator.StartOfNextLocalMonth(startOfMonth)
+	}
+	return startOfMonth
+}
@@ -0,0 +1,96 @@
+// Package embedded_tzdata provides LocalDaysCalculators that do not depend on the tzdata installed on the system (which is often missing, e.g. in distroless containers).
+// It embeds a pinned snapshot of the IANA tzdata (see Version), which adds about 400KB to your binary. Import it only if you need it.
+package embedded_tzdata
+
+//go:generate go run generate.go
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"sync"
+	"time"
+
+	_ "embed" // for the tzdata snapshot
+
+	"github.com/hochfrequenz/go-local-days/local_days"
+)
+
+//go:embed zoneinfo.zip
+var zoneinfoZip []byte
+
+var (
+	zoneinfoOnce   sync.Once
+	zoneinfoFiles  map[string]*zip.File
+	zoneinfoZipErr error
+)
+
+// LoadLocation returns the location for zoneName (e.g. "Europe/Berlin") from the embedded tzdata.
+func LoadLocation(zoneName string) (*time.Location, error) {
+	data, err := TZData(zoneName)
+	if err != nil {
+		return nil, err
+	}
+	return time.LoadLocationFromTZData(zoneName, data)
+}
+
+// TZData returns the TZif data for zoneName (e.g. "Europe/Berlin") from the embedded tzdata, e.g. for local_days.NewTZDataBasedLocalTimeConverter.
+func TZData(zoneName string) ([]byte, error) {
+	if err := readZoneinfo(); err != nil {
+		return nil, err
+	}
+	file, found := zoneinfoFiles[zoneName]
+	if !found {
+		return nil, fmt.Errorf("The timezone '%s' is not part of the embedded tzdata %s", zoneName, Version)
+	}
+	content, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+	return ioutil.ReadAll(content)
+}
+
+// ZoneNames returns the names of all zones in the embedded tzdata in alphabetical order, e.g. for tests that are run for every zone.
+func ZoneNames() ([]string, error) {
+	if err := readZoneinfo(); err != nil {
+		return nil, err
+	}
+	zoneNames := make([]string, 0, len(zoneinfoFiles))
+	for zoneName := range zoneinfoFiles {
+		zoneNames = append(zoneNames, zoneName)
+	}
+	sort.Strings(zoneNames)
+	return zoneNames, nil
+}
+
+// readZoneinfo indexes the files in the embedded zoneinfo.zip by zone name, once.
+func readZoneinfo() error {
+	zoneinfoOnce.Do(func() {
+		reader, err := zip.NewReader(bytes.NewReader(zoneinfoZip), int64(len(zoneinfoZip)))
+		if err != nil {
+			zoneinfoZipErr = err
+			return
+		}
+		zoneinfoFiles = make(map[string]*zip.File, len(reader.File))
+		for _, file := range reader.File {
+			zoneinfoFiles[file.Name] = file
+		}
+	})
+	if zoneinfoZipErr != nil {
+		return fmt.Errorf("The embedded tzdata could not be read: %w", zoneinfoZipErr)
+	}
+	return nil
+}
+
+// NewTimeZoneBasedLocalTimeConverter returns a LocalDaysCalculator for zoneName (e.g. "Europe/Berlin") that is based on the embedded tzdata, regardless of the tzdata installed on the system.
+// The options are the same as for local_days.NewTimeZoneBasedLocalTimeConverter. Other than local_days.NewTimeZoneBasedLocalTimeConverter, it returns an error instead of panicking if the zone is unknown. The calculator reports the Version of the embedded tzdata in local_days.TZDataVersion.
+func NewTimeZoneBasedLocalTimeConverter(zoneName string, options ...local_days.Option) (local_days.LocalDaysCalculator, error) {
+	location, err := LoadLocation(zoneName)
+	if err != nil {
+		return nil, err
+	}
+	return local_days.NewVersionedTimeZoneBasedLocalTimeConverter(zoneName, location, Version, options...), nil
+}
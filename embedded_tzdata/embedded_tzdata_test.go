@@ -0,0 +1,89 @@
+package embedded_tzdata_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/corbym/gocrest/is"
+	"github.com/corbym/gocrest/then"
+	"github.com/hochfrequenz/go-local-days/conformance"
+	"github.com/hochfrequenz/go-local-days/embedded_tzdata"
+	"github.com/hochfrequenz/go-local-days/local_days"
+	"github.com/stretchr/testify/suite"
+)
+
+// Test_Embedded_Berlin tests that a calculator for Germany can be created from the embedded tzdata.
+func (s *Suite) Test_Embedded_Berlin() {
+	berlin, err := embedded_tzdata.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin")
+	then.AssertThat(s.T(), err, is.Nil())
+	actual := berlin.StartOfLocalDay(time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), actual, is.EqualTo(time.Date(2022, 5, 31, 22, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), conformance.Check(berlin, 2020, 2025), is.Empty())
+}
+
+// Test_Embedded_Version tests that the calculator reports the version of the embedded tzdata.
+func (s *Suite) Test_Embedded_Version() {
+	berlin, err := embedded_tzdata.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin")
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), local_days.TZDataVersion(berlin), is.EqualTo(embedded_tzdata.Version))
+	then.AssertThat(s.T(), embedded_tzdata.Version, is.MatchForPattern(`^\d{4}[a-z]$`))
+}
+
+// Test_Embedded_Calculator_Is_Not_Wrapped tests that the calculator can be precomputed and described by a spec like the one from the system tzdata.
+func (s *Suite) Test_Embedded_Calculator_Is_Not_Wrapped() {
+	berlin, err := embedded_tzdata.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin", local_days.WithDayStart(6*time.Hour))
+	then.AssertThat(s.T(), err, is.Nil())
+	precomputed, err := local_days.NewPrecomputedLocalDaysCalculator(berlin, 2022, 2022)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), local_days.TZDataVersion(precomputed), is.EqualTo(embedded_tzdata.Version))
+	spec, err := local_days.SpecOf(berlin)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), spec, is.EqualTo(local_days.CalculatorSpec{ZoneName: "Europe/Berlin", DayStart: "06:00"}))
+}
+
+// Test_Embedded_Zone_Names tests that all zones of the embedded tzdata are listed and can be loaded.
+func (s *Suite) Test_Embedded_Zone_Names() {
+	zoneNames, err := embedded_tzdata.ZoneNames()
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), len(zoneNames), is.GreaterThan(400))
+	then.AssertThat(s.T(), zoneNames, is.ValueContaining("Europe/Berlin"))
+	for _, zoneName := range zoneNames {
+		_, err := embedded_tzdata.LoadLocation(zoneName)
+		then.AssertThat(s.T(), err, is.Nil())
+	}
+}
+
+// Test_Embedded_TZData tests that the raw TZif data of the embedded tzdata can be used to create calculators.
+func (s *Suite) Test_Embedded_TZData() {
+	data, err := embedded_tzdata.TZData("Europe/Berlin")
+	then.AssertThat(s.T(), err, is.Nil())
+	berlin, err := local_days.NewTZDataBasedLocalTimeConverter("Europe/Berlin", data)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), berlin.StartOfLocalDay(time.Date(2022, 10, 30, 12, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2022, 10, 29, 22, 0, 0, 0, time.UTC)))
+	_, err = embedded_tzdata.TZData("Europe/Springfield")
+	then.AssertThat(s.T(), err, is.Not(is.Nil()))
+}
+
+// Test_Embedded_Unknown_Zone tests that unknown zones are reported as error instead of panicking.
+func (s *Suite) Test_Embedded_Unknown_Zone() {
+	_, err := embedded_tzdata.NewTimeZoneBasedLocalTimeConverter("Europe/Springfield")
+	then.AssertThat(s.T(), err, is.Not(is.Nil()))
+}
+
+// ----------------------------
+// test framework boiler plate
+// ---------------------------
+type Suite struct {
+	suite.Suite
+}
+
+// SetupSuite sets up the tests
+func (s *Suite) SetupSuite() {
+}
+
+func (s *Suite) AfterTest(_, _ string) {
+}
+
+func TestInit(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
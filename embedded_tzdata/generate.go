@@ -0,0 +1,38 @@
+//go:build ignore
+// +build ignore
+
+// This program updates the embedded tzdata to the tzdata of the installed Go distribution. Run it using "go generate".
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"regexp"
+	"runtime"
+)
+
+func main() {
+	goTimeDirectory := filepath.Join(runtime.GOROOT(), "lib", "time")
+	zoneinfo, err := ioutil.ReadFile(filepath.Join(goTimeDirectory, "zoneinfo.zip"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	// the release of the tzdata is only recorded in the script that built the zoneinfo.zip
+	updateScript, err := ioutil.ReadFile(filepath.Join(goTimeDirectory, "update.bash"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	match := regexp.MustCompile(`(?m)^DATA=(\S+)$`).FindSubmatch(updateScript)
+	if match == nil {
+		log.Fatal("The tzdata release could not be found in update.bash")
+	}
+	version := fmt.Sprintf("// Code generated by generate.go; DO NOT EDIT.\n\npackage embedded_tzdata\n\n// Version is the release of the IANA tzdata that is embedded in this package.\nconst Version = %q\n", match[1])
+	if err = ioutil.WriteFile("zoneinfo.zip", zoneinfo, 0o644); err != nil {
+		log.Fatal(err)
+	}
+	if err = ioutil.WriteFile("version.go", []byte(version), 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
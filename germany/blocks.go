@@ -0,0 +1,268 @@
+package germany
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hochfrequenz/go-local-days/local_days"
+)
+
+// BlockWindow is a daily recurring window of local clock times of a Block.
+type BlockWindow struct {
+	// Weekdays are the local weekdays on which the window applies
+	Weekdays []time.Weekday
+	// From is the local clock time at which the window starts, e.g. 8h
+	From time.Duration
+	// To is the local clock time at which the window ends (exclusive), e.g. 20h or 24h for the end of the day. It has to be after From, windows that span midnight consist of two windows.
+	To time.Duration
+}
+
+// Block is a block product over local hours, e.g. "Peak Mo-Fr 08-20". Use ParseBlock to create blocks from their compact notation and Expand to get their UTC intervals.
+type Block struct {
+	// Name is the name of the block, e.g. "Peak". It may be empty.
+	Name string
+	// Windows are the local clock time windows of the block
+	Windows []BlockWindow
+}
+
+var (
+	workingDays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	allDays     = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}
+	weekend     = []time.Weekday{time.Saturday, time.Sunday}
+)
+
+var (
+	// BaseBlock are all hours: "Base Mo-Su 00-24".
+	BaseBlock = Block{Name: "Base", Windows: []BlockWindow{{Weekdays: allDays, From: 0, To: 24 * time.Hour}}}
+	// PeakBlock are the peak hours of the German market area (see FuturesCalendar.Peak), including holidays: "Peak Mo-Fr 08-20".
+	PeakBlock = Block{Name: "Peak", Windows: []BlockWindow{{Weekdays: workingDays, From: 8 * time.Hour, To: 20 * time.Hour}}}
+	// OffPeakBlock are all hours that are not PeakBlock hours: "Off-Peak Mo-Fr 00-08, Mo-Fr 20-24, Sa-Su 00-24".
+	OffPeakBlock = Block{Name: "Off-Peak", Windows: []BlockWindow{{Weekdays: workingDays, From: 0, To: 8 * time.Hour}, {Weekdays: workingDays, From: 20 * time.Hour, To: 24 * time.Hour}, {Weekdays: weekend, From: 0, To: 24 * time.Hour}}}
+	// OffPeak1Block are the working day hours before the peak hours: "Off-Peak 1 Mo-Fr 00-08".
+	OffPeak1Block = Block{Name: "Off-Peak 1", Windows: []BlockWindow{{Weekdays: workingDays, From: 0, To: 8 * time.Hour}}}
+	// OffPeak2Block are the working day hours after the peak hours: "Off-Peak 2 Mo-Fr 20-24".
+	OffPeak2Block = Block{Name: "Off-Peak 2", Windows: []BlockWindow{{Weekdays: workingDays, From: 20 * time.Hour, To: 24 * time.Hour}}}
+)
+
+var predefinedBlocks = []Block{BaseBlock, PeakBlock, OffPeakBlock, OffPeak1Block, OffPeak2Block}
+
+var weekdayAbbreviations = map[string]time.Weekday{"mo": time.Monday, "tu": time.Tuesday, "we": time.Wednesday, "th": time.Thursday, "fr": time.Friday, "sa": time.Saturday, "su": time.Sunday}
+
+// ParseBlock parses the compact notation of a block: an optional name followed by comma separated windows "[weekdays] hh[:mm]-hh[:mm]", e.g. "Peak Mo-Fr 08-20", "Off-Peak 1 00-08" or "Weekend Sa-Su 00-24, Mo 00-06".
+// The weekdays are a two-letter English abbreviation ("Mo", "Tu", "We", "Th", "Fr", "Sa", "Su") or a range of them (e.g. "Mo-Fr" or "Sa-Mo"), windows without weekdays apply on all days. The name alone refers to a predefined block ("Base", "Peak", "Off-Peak", "Off-Peak 1" or "Off-Peak 2").
+func ParseBlock(notation string) (Block, error) {
+	parts := strings.Split(strings.ReplaceAll(notation, "–", "-"), ",")
+	tokens := strings.Fields(parts[0])
+	if len(tokens) == 0 {
+		return Block{}, fmt.Errorf("The block notation '%s' is empty", notation)
+	}
+	if _, _, err := parseBlockHours(tokens[len(tokens)-1]); err != nil && len(parts) == 1 {
+		name := strings.Join(tokens, " ")
+		for _, block := range predefinedBlocks {
+			if strings.EqualFold(block.Name, name) {
+				return block, nil
+			}
+		}
+		return Block{}, fmt.Errorf("The block notation '%s' has no windows and '%s' is not a predefined block", notation, name)
+	}
+	// the first part consists of the name and the first window, the first window starts with its weekdays (if any)
+	windowStart := len(tokens) - 1
+	if windowStart > 0 {
+		if _, err := parseBlockWeekdays(tokens[windowStart-1]); err == nil {
+			windowStart--
+		} else if candidate := tokens[windowStart-1]; len(candidate) == 5 && candidate[2] == '-' {
+			// a name that looks like a range of weekdays is most likely a typo
+			return Block{}, fmt.Errorf("The weekdays '%s' of the block notation '%s' are invalid: %w", candidate, notation, err)
+		}
+	}
+	block := Block{Name: strings.Join(tokens[:windowStart], " ")}
+	parts[0] = strings.Join(tokens[windowStart:], " ")
+	for _, part := range parts {
+		window, err := parseBlockWindow(part)
+		if err != nil {
+			return Block{}, fmt.Errorf("The window '%s' of the block notation '%s' is invalid: %w", strings.TrimSpace(part), notation, err)
+		}
+		block.Windows = append(block.Windows, window)
+	}
+	return block, nil
+}
+
+func parseBlockWindow(notation string) (BlockWindow, error) {
+	tokens := strings.Fields(notation)
+	window := BlockWindow{Weekdays: allDays}
+	switch len(tokens) {
+	case 1:
+	case 2:
+		days, err := parseBlockWeekdays(tokens[0])
+		if err != nil {
+			return window, err
+		}
+		window.Weekdays = days
+	default:
+		return window, fmt.Errorf("expected '[weekdays] hh[:mm]-hh[:mm]'")
+	}
+	var err error
+	window.From, window.To, err = parseBlockHours(tokens[len(tokens)-1])
+	return window, err
+}
+
+// parseBlockWeekdays parses a weekday abbreviation or a range of them, e.g. "Mo-Fr".
+func parseBlockWeekdays(notation string) ([]time.Weekday, error) {
+	bounds := strings.Split(strings.ToLower(notation), "-")
+	if len(bounds) > 2 {
+		return nil, fmt.Errorf("expected a weekday or a range of weekdays, e.g. 'Mo-Fr'")
+	}
+	first, ok := weekdayAbbreviations[bounds[0]]
+	last, lastOk := weekdayAbbreviations[bounds[len(bounds)-1]]
+	if !ok || !lastOk {
+		return nil, fmt.Errorf("expected weekdays as 'Mo', 'Tu', 'We', 'Th', 'Fr', 'Sa' or 'Su'")
+	}
+	days := []time.Weekday{first}
+	for day := first; day != last; {
+		day = (day + 1) % 7
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// parseBlockHours parses "hh[:mm]-hh[:mm]" as local clock times.
+func parseBlockHours(notation string) (time.Duration, time.Duration, error) {
+	bounds := strings.Split(notation, "-")
+	if len(bounds) != 2 {
+		return 0, 0, fmt.Errorf("expected hours as 'hh[:mm]-hh[:mm]'")
+	}
+	from, err := parseBlockClock(bounds[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	to, err := parseBlockClock(bounds[1])
+	if err != nil {
+		return 0, 0, err
+	}
+	if to <= from {
+		return 0, 0, fmt.Errorf("the end %s is not after the start %s, split windows that span midnight", bounds[1], bounds[0])
+	}
+	return from, to, nil
+}
+
+func parseBlockClock(notation string) (time.Duration, error) {
+	if len(notation) == 2 {
+		notation += ":00"
+	}
+	clock, err := parseTariffClock(notation)
+	return time.Duration(clock) * time.Second, err
+}
+
+// String returns the compact notation of the block that is understood by ParseBlock.
+func (b Block) String() string {
+	var windows []string
+	for _, window := range b.Windows {
+		windows = append(windows, window.String())
+	}
+	return strings.TrimSpace(b.Name + " " + strings.Join(windows, ", "))
+}
+
+// String returns the compact notation of the window. Weekdays that are not a single range are written as multiple windows.
+func (w BlockWindow) String() string {
+	hours := formatBlockClock(w.From) + "-" + formatBlockClock(w.To)
+	included := map[time.Weekday]bool{}
+	for _, day := range w.Weekdays {
+		included[day] = true
+	}
+	if len(included) == 7 {
+		return hours
+	}
+	var windows []string
+	for i := 0; i < len(allDays); i++ {
+		if !included[allDays[i]] {
+			continue
+		}
+		first := i
+		for i+1 < len(allDays) && included[allDays[i+1]] {
+			i++
+		}
+		days := abbreviation(allDays[first])
+		if i > first {
+			days += "-" + abbreviation(allDays[i])
+		}
+		windows = append(windows, days+" "+hours)
+	}
+	return strings.Join(windows, ", ")
+}
+
+func abbreviation(weekday time.Weekday) string {
+	return weekday.String()[:2]
+}
+
+func formatBlockClock(clock time.Duration) string {
+	hours, minutes := int(clock/time.Hour), int(clock%time.Hour/time.Minute)
+	if minutes == 0 {
+		return fmt.Sprintf("%02d", hours)
+	}
+	return fmt.Sprintf("%02d:%02d", hours, minutes)
+}
+
+// Expand returns the UTC intervals of the block within period, sorted and with adjacent or overlapping intervals merged (e.g. BaseBlock expands to period itself).
+// The windows refer to the local dates and clock times of calculator, so a window that contains the hour that is skipped at the start of DST is an hour shorter and a window that contains the hour that is repeated at the end of DST is an hour longer.
+// It returns an *local_days.InvalidArgumentError if the calculator is not based on a time.Location (see local_days.Location), because then the local clock times of the windows are unknown.
+func (b Block) Expand(calculator local_days.LocalDaysCalculator, period local_days.Interval) ([]local_days.Interval, error) {
+	location, err := locationOf("Block.Expand", calculator)
+	if err != nil {
+		return nil, err
+	}
+	return b.expand(calculator, location, period), nil
+}
+
+// expand returns the UTC intervals of the block within period (see Expand) for a calculator whose location is known.
+func (b Block) expand(calculator local_days.LocalDaysCalculator, location *time.Location, period local_days.Interval) []local_days.Interval {
+	var intervals []local_days.Interval
+	for startOfDay := calculator.StartOfLocalDay(period.Start); startOfDay.Before(period.End); startOfDay = calculator.StartOfNextLocalDay(startOfDay) {
+		date, _ := local_days.LocalDate(calculator, startOfDay)
+		// if the local days do not start at midnight, they cover the clock times of two local dates (the resulting duplicates are merged)
+		for _, localDate := range []time.Time{date, date.AddDate(0, 0, 1)} {
+			for _, window := range b.Windows {
+				if !containsWeekday(window.Weekdays, localDate.Weekday()) {
+					continue
+				}
+				start, end := local_days.LocalClockTimes(location, localDate, window.From)[0], local_days.LocalClockTimes(location, localDate, window.To)[0]
+				if start.Before(period.Start) {
+					start = period.Start
+				}
+				if end.After(period.End) {
+					end = period.End
+				}
+				if end.After(start) {
+					intervals = append(intervals, local_days.Interval{Start: start, End: end})
+				}
+			}
+		}
+	}
+	return mergeIntervals(intervals)
+}
+
+func containsWeekday(days []time.Weekday, weekday time.Weekday) bool {
+	for _, day := range days {
+		if day == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeIntervals sorts the intervals and merges the adjacent or overlapping ones.
+func mergeIntervals(intervals []local_days.Interval) []local_days.Interval {
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i].Start.Before(intervals[j].Start) })
+	var merged []local_days.Interval
+	for _, interval := range intervals {
+		if last := len(merged) - 1; last >= 0 && !interval.Start.After(merged[last].End) {
+			if interval.End.After(merged[last].End) {
+				merged[last].End = interval.End
+			}
+			continue
+		}
+		merged = append(merged, interval)
+	}
+	return merged
+}
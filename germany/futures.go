@@ -0,0 +1,146 @@
+package germany
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hochfrequenz/go-local-days/local_days"
+)
+
+// FuturesSeason is a delivery season of futures.
+type FuturesSeason int
+
+const (
+	// SummerSeason is the delivery season from April until September.
+	SummerSeason FuturesSeason = iota + 1
+	// WinterSeason is the delivery season from October until March of the following year.
+	WinterSeason
+)
+
+func (s FuturesSeason) String() string {
+	switch s {
+	case SummerSeason:
+		return "Summer"
+	case WinterSeason:
+		return "Winter"
+	}
+	return "unknown"
+}
+
+// FuturesCalendar returns the delivery periods of futures (months, quarters, seasons and years) and their base, peak and off-peak hours. Create it with NewFuturesCalendar or NewGermanFuturesCalendar.
+type FuturesCalendar struct {
+	calculator local_days.LocalDaysCalculator
+	location   *time.Location
+}
+
+// NewFuturesCalendar returns a FuturesCalendar whose delivery periods consist of the local days of calculator. It returns an *local_days.InvalidArgumentError if the calculator is not based on a time.Location (see local_days.Location), because then the local clock times of the peak hours are unknown.
+func NewFuturesCalendar(calculator local_days.LocalDaysCalculator) (FuturesCalendar, error) {
+	location, err := locationOf("NewFuturesCalendar", calculator)
+	if err != nil {
+		return FuturesCalendar{}, err
+	}
+	return FuturesCalendar{calculator: calculator, location: location}, nil
+}
+
+// NewGermanFuturesCalendar returns the FuturesCalendar of the German market area, whose delivery periods consist of German local days.
+func NewGermanFuturesCalendar() FuturesCalendar {
+	calendar, err := NewFuturesCalendar(NewGermanLocalDaysCalculator())
+	if err != nil {
+		log.Panic(err)
+	}
+	return calendar
+}
+
+// Month returns the delivery period of the local month, e.g. 2023-02-28 23:00 UTC to 2023-03-31 22:00 UTC (743 hours) for March 2023 in Germany.
+func (c FuturesCalendar) Month(year int, month time.Month) local_days.Interval {
+	// the middle of the month in UTC is within the local month for all UTC offsets and day starts
+	middle := time.Date(year, month, 15, 12, 0, 0, 0, time.UTC)
+	return local_days.Interval{Start: c.calculator.StartOfLocalMonth(middle), End: c.calculator.StartOfNextLocalMonth(middle)}
+}
+
+// Quarter returns the delivery period of the quarter (1-4) of the year. It panics if quarter is not from 1 to 4.
+func (c FuturesCalendar) Quarter(year int, quarter int) local_days.Interval {
+	if quarter < 1 || quarter > 4 {
+		log.Panic(fmt.Errorf("The quarter %d is not from 1 to 4", quarter))
+	}
+	firstMonth := time.Month(3*quarter - 2)
+	return local_days.Interval{Start: c.Month(year, firstMonth).Start, End: c.Month(year, firstMonth+2).End}
+}
+
+// Season returns the delivery period of the season that starts in the year, e.g. October 2023 until March 2024 for the WinterSeason of 2023. It panics if season is invalid.
+func (c FuturesCalendar) Season(year int, season FuturesSeason) local_days.Interval {
+	switch season {
+	case SummerSeason:
+		return local_days.Interval{Start: c.Month(year, time.April).Start, End: c.Month(year, time.September).End}
+	case WinterSeason:
+		return local_days.Interval{Start: c.Month(year, time.October).Start, End: c.Month(year+1, time.March).End}
+	}
+	log.Panic(fmt.Errorf("The season %d is invalid", season))
+	return local_days.Interval{}
+}
+
+// Year returns the delivery period of the calendar year.
+func (c FuturesCalendar) Year(year int) local_days.Interval {
+	return local_days.Interval{Start: c.Month(year, time.January).Start, End: c.Month(year, time.December).End}
+}
+
+// BaseHours returns the number of (started) hours of the delivery period, e.g. 743 for March and 745 for October in Germany.
+func (c FuturesCalendar) BaseHours(period local_days.Interval) int {
+	count := 0
+	c.eachHour(period, func(local_days.Interval) { count++ })
+	return count
+}
+
+// PeakHours returns the number of hours of the delivery period that start within the peak block, e.g. PeakBlock for the German peak hours (Monday to Friday from 08:00 to 20:00 local time, including holidays).
+func (c FuturesCalendar) PeakHours(period local_days.Interval, peak Block) int {
+	return len(c.Peak(period, peak))
+}
+
+// OffPeakHours returns the number of hours of the delivery period that start outside the peak block.
+func (c FuturesCalendar) OffPeakHours(period local_days.Interval, peak Block) int {
+	return c.BaseHours(period) - c.PeakHours(period, peak)
+}
+
+// Hours returns the hours of the delivery period, the last one is shortened if the period does not consist of whole hours.
+func (c FuturesCalendar) Hours(period local_days.Interval) []local_days.Interval {
+	var hours []local_days.Interval
+	c.eachHour(period, func(hour local_days.Interval) { hours = append(hours, hour) })
+	return hours
+}
+
+// Peak returns the hours of the delivery period that start within the peak block (see Block.Expand).
+func (c FuturesCalendar) Peak(period local_days.Interval, peak Block) []local_days.Interval {
+	return c.hoursStartingWithin(period, peak, true)
+}
+
+// OffPeak returns the hours of the delivery period that start outside the peak block.
+func (c FuturesCalendar) OffPeak(period local_days.Interval, peak Block) []local_days.Interval {
+	return c.hoursStartingWithin(period, peak, false)
+}
+
+// hoursStartingWithin returns the hours of the delivery period that start within the block (or outside of it, if within is false).
+func (c FuturesCalendar) hoursStartingWithin(period local_days.Interval, block Block, within bool) []local_days.Interval {
+	// the intervals of the block are sorted and don't overlap, so we walk through them along with the hours
+	intervals := block.expand(c.calculator, c.location, period)
+	var hours []local_days.Interval
+	c.eachHour(period, func(hour local_days.Interval) {
+		for len(intervals) > 0 && !intervals[0].End.After(hour.Start) {
+			intervals = intervals[1:]
+		}
+		if starts := len(intervals) > 0 && !intervals[0].Start.After(hour.Start); starts == within {
+			hours = append(hours, hour)
+		}
+	})
+	return hours
+}
+
+func (c FuturesCalendar) eachHour(period local_days.Interval, visit func(hour local_days.Interval)) {
+	for start := period.Start; start.Before(period.End); start = start.Add(time.Hour) {
+		end := start.Add(time.Hour)
+		if end.After(period.End) {
+			end = period.End
+		}
+		visit(local_days.Interval{Start: start, End: end})
+	}
+}
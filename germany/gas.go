@@ -0,0 +1,81 @@
+package germany
+
+import (
+	"log"
+	"time"
+
+	"github.com/hochfrequenz/go-local-days/local_days"
+)
+
+// gasDaySpec describes the German gas day, which starts at 06:00 German local time (see GaBi Gas).
+var gasDaySpec = local_days.CalculatorSpec{ZoneName: "Europe/Berlin", DayStart: "06:00"}
+
+// NewGermanGasDaysCalculator returns a converter whose local days are German gas days, i.e. they start at 06:00 German local time instead of midnight. Its local months are gas months, which start on the first day of the month at 06:00.
+// The calculator is cached in the local_days.DefaultRegistry. It panics if the tzdata is not available.
+func NewGermanGasDaysCalculator() local_days.LocalDaysCalculator {
+	calculator, err := local_days.DefaultRegistry.GetSpec(gasDaySpec)
+	if err != nil {
+		log.Panic(err)
+	}
+	return calculator
+}
+
+// StartOfGasMonth returns the start of the gas month of timestamp as UTC. A gas month starts on the first day of the month at 06:00 German local time.
+func StartOfGasMonth(timestamp time.Time) time.Time {
+	return NewGermanGasDaysCalculator().StartOfLocalMonth(timestamp)
+}
+
+// StartOfNextGasMonth returns the start of the gas month after the gas month of timestamp as UTC.
+func StartOfNextGasMonth(timestamp time.Time) time.Time {
+	return NewGermanGasDaysCalculator().StartOfNextLocalMonth(timestamp)
+}
+
+// GasYear returns the gas year (Gaswirtschaftsjahr) of timestamp as the calendar year in which it starts, e.g. 2022 for the gas year 2022/2023 which starts on 2022-10-01 at 06:00 German local time.
+func GasYear(timestamp time.Time) int {
+	gasDays := NewGermanGasDaysCalculator()
+	// the gas month starts on the first day of its month, so its local date is the date of the gas month
+	year, month, _ := gasDays.StartOfLocalMonth(timestamp).In(local_days.Location(gasDays)).Date()
+	if month < time.October {
+		return year - 1
+	}
+	return year
+}
+
+// StartOfGasYear returns the start of the gas year (Gaswirtschaftsjahr) of timestamp as UTC. A gas year starts on 1 October at 06:00 German local time.
+func StartOfGasYear(timestamp time.Time) time.Time {
+	return startOfGasYear(GasYear(timestamp))
+}
+
+// StartOfNextGasYear returns the start of the gas year after the gas year of timestamp as UTC.
+func StartOfNextGasYear(timestamp time.Time) time.Time {
+	return startOfGasYear(GasYear(timestamp) + 1)
+}
+
+// startOfGasYear returns the start of the gas year that starts in the given calendar year.
+func startOfGasYear(year int) time.Time {
+	gasDays := NewGermanGasDaysCalculator()
+	return gasDays.StartOfLocalDay(time.Date(year, time.October, 1, 12, 0, 0, 0, local_days.Location(gasDays)).UTC())
+}
+
+// GasDaysOfLocalDay returns the gas days that overlap with the German local day of timestamp, in chronological order. These are always two: the gas day that started at 06:00 on the previous local date and the one that starts at 06:00 on the local date of timestamp.
+func GasDaysOfLocalDay(timestamp time.Time) []local_days.Interval {
+	berlin := NewGermanLocalDaysCalculator()
+	return overlappingDays(NewGermanGasDaysCalculator(), berlin.StartOfLocalDay(timestamp), berlin.StartOfNextLocalDay(timestamp))
+}
+
+// LocalDaysOfGasDay returns the German local days that overlap with the gas day of timestamp, in chronological order. These are always two: the local day on which the gas day starts and the following one.
+func LocalDaysOfGasDay(timestamp time.Time) []local_days.Interval {
+	gasDays := NewGermanGasDaysCalculator()
+	return overlappingDays(NewGermanLocalDaysCalculator(), gasDays.StartOfLocalDay(timestamp), gasDays.StartOfNextLocalDay(timestamp))
+}
+
+// overlappingDays returns the local days of calculator that overlap with [from, to).
+func overlappingDays(calculator local_days.LocalDaysCalculator, from time.Time, to time.Time) []local_days.Interval {
+	var days []local_days.Interval
+	for startOfDay := calculator.StartOfLocalDay(from); startOfDay.Before(to); {
+		startOfNextDay := calculator.StartOfNextLocalDay(startOfDay)
+		days = append(days, local_days.Interval{Start: startOfDay, End: startOfNextDay})
+		startOfDay = startOfNextDay
+	}
+	return days
+}
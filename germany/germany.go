@@ -1,11 +1,30 @@
 package germany
 
 import (
+	"log"
+	"time"
+
 	"github.com/hochfrequenz/go-local-days/local_days"
 )
 
 // NewGermanLocalDaysCalculator returns a converter that works for Germany. Internally it's based on the local_days.NewTimeZoneBasedLocalTimeConverter and tzdata for "Europe/Berlin".
+// The calculator is cached in the local_days.DefaultRegistry, so the tzdata is only loaded once. It panics if the tzdata is not available.
 func NewGermanLocalDaysCalculator() local_days.LocalDaysCalculator {
 	const zoneName = "Europe/Berlin"
-	return local_days.NewTimeZoneBasedLocalTimeConverter(zoneName)
+	calculator, err := local_days.Get(zoneName)
+	if err != nil {
+		log.Panic(err)
+	}
+	return calculator
+}
+
+// NewGermanStandardTimeLocalDaysCalculator returns a converter for German standard time (MEZ/CET, UTC+1) all year long, without daylight saving time. Some gas and heat contracts are defined in "MEZ all year".
+func NewGermanStandardTimeLocalDaysCalculator() local_days.LocalDaysCalculator {
+	return local_days.NewFixedOffsetLocalTimeConverter(time.Hour)
+}
+
+// CompareWithGermanLocalDays returns the local days of the given calculator that start within [from, to) and start at a different point in time than the same day in Germany (according to NewGermanLocalDaysCalculator).
+// The Other start of each difference is the start of the German local day. This is useful for reconciliation reports, e.g. for contracts in German standard time (NewGermanStandardTimeLocalDaysCalculator).
+func CompareWithGermanLocalDays(calculator local_days.LocalDaysCalculator, from time.Time, to time.Time) []local_days.StartDifference {
+	return local_days.CompareLocalDayStarts(calculator, NewGermanLocalDaysCalculator(), from, to)
 }
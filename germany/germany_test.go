@@ -1,9 +1,11 @@
 package germany_test
 
 import (
+	"errors"
 	"github.com/corbym/gocrest/is"
 	"github.com/corbym/gocrest/then"
 	"github.com/hochfrequenz/go-local-days/germany"
+	"github.com/hochfrequenz/go-local-days/local_days"
 	"github.com/stretchr/testify/suite"
 	"testing"
 	"time"
@@ -45,6 +47,14 @@ func (s *Suite) Test_Add_Local_Day_Normal_CEST_To_CET_Transition() {
 	then.AssertThat(s.T(), actual, is.EqualTo(time.Date(2022, 10, 31, 1, 0, 0, 0, time.UTC)))
 }
 
+// Test_Add_Many_Local_Days tests that adding more local days than fit into a time.Duration works, too.
+func (s *Suite) Test_Add_Many_Local_Days() {
+	berlin := germany.NewGermanLocalDaysCalculator()
+	date := time.Date(2022, 10, 30, 0, 0, 0, 0, time.UTC)
+	then.AssertThat(s.T(), berlin.AddLocalDays(date, 200000), is.EqualTo(time.Date(2570, 5, 30, 0, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), berlin.AddLocalDays(date, -200000), is.EqualTo(time.Date(1475, 4, 1, 1, 6, 32, 0, time.UTC))) // local mean time (UTC+00:53:28)
+}
+
 /*******************
  Start of Local Day
 *******************/
@@ -292,6 +302,467 @@ func (s *Suite) Test_Is_Local_Midnight_CEST() {
 	then.AssertThat(s.T(), berlin.IsLocalMidnight(notMidnight), is.False())
 }
 
+/**********************
+ German Standard Time
+**********************/
+
+// Test_German_Standard_Time tests that local days in German standard time always start at 23:00 UTC.
+func (s *Suite) Test_German_Standard_Time() {
+	mez := germany.NewGermanStandardTimeLocalDaysCalculator()
+	then.AssertThat(s.T(), mez.StartOfLocalDay(time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2021, 12, 31, 23, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), mez.StartOfLocalDay(time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2022, 5, 31, 23, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), mez.AddLocalDays(time.Date(2022, 3, 27, 0, 0, 0, 0, time.UTC), 1), is.EqualTo(time.Date(2022, 3, 28, 0, 0, 0, 0, time.UTC)))
+}
+
+// Test_Compare_With_German_Local_Days tests that the local days in German standard time differ from the German local days during daylight saving time.
+func (s *Suite) Test_Compare_With_German_Local_Days() {
+	mez := germany.NewGermanStandardTimeLocalDaysCalculator()
+	differences := germany.CompareWithGermanLocalDays(mez, time.Date(2021, 12, 31, 23, 0, 0, 0, time.UTC), time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC))
+	// 2022-03-28 (the first full day in CEST) until 2022-10-30 (the day on which CEST ends)
+	then.AssertThat(s.T(), len(differences), is.EqualTo(217))
+	then.AssertThat(s.T(), differences[0].Reference, is.EqualTo(time.Date(2022, 3, 27, 23, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), differences[0].Other, is.EqualTo(time.Date(2022, 3, 27, 22, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), differences[0].Shift(), is.EqualTo(-time.Hour))
+	then.AssertThat(s.T(), differences[216].Reference, is.EqualTo(time.Date(2022, 10, 29, 23, 0, 0, 0, time.UTC)))
+}
+
+// Test_Compare_With_German_Local_Days_No_Differences tests that the German calculator does not differ from itself.
+func (s *Suite) Test_Compare_With_German_Local_Days_No_Differences() {
+	berlin := germany.NewGermanLocalDaysCalculator()
+	differences := germany.CompareWithGermanLocalDays(berlin, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), differences, is.Empty())
+}
+
+/***************
+ Gas Calendar
+***************/
+
+// Test_Gas_Day tests that gas days start at 06:00 German local time, also on the days of the DST transitions.
+func (s *Suite) Test_Gas_Day() {
+	gasDays := germany.NewGermanGasDaysCalculator()
+	then.AssertThat(s.T(), gasDays.StartOfLocalDay(time.Date(2022, 11, 16, 4, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2022, 11, 15, 5, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), gasDays.StartOfLocalDay(time.Date(2022, 11, 16, 5, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2022, 11, 16, 5, 0, 0, 0, time.UTC)))
+	// the gas day from 2022-03-26 06:00 CET until 2022-03-27 06:00 CEST has 23h
+	then.AssertThat(s.T(), gasDays.StartOfNextLocalDay(time.Date(2022, 3, 26, 12, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2022, 3, 27, 4, 0, 0, 0, time.UTC)))
+	// the gas day from 2022-10-29 06:00 CEST until 2022-10-30 06:00 CET has 25h
+	then.AssertThat(s.T(), gasDays.StartOfNextLocalDay(time.Date(2022, 10, 29, 12, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2022, 10, 30, 5, 0, 0, 0, time.UTC)))
+}
+
+// Test_Gas_Month tests that gas months start on the first day of the month at 06:00 German local time.
+func (s *Suite) Test_Gas_Month() {
+	then.AssertThat(s.T(), germany.StartOfGasMonth(time.Date(2022, 11, 1, 4, 59, 0, 0, time.UTC)), is.EqualTo(time.Date(2022, 10, 1, 4, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), germany.StartOfGasMonth(time.Date(2022, 11, 1, 5, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2022, 11, 1, 5, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), germany.StartOfNextGasMonth(time.Date(2022, 3, 15, 0, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2022, 4, 1, 4, 0, 0, 0, time.UTC)))
+}
+
+// Test_Gas_Year tests that gas years start on 1 October at 06:00 German local time.
+func (s *Suite) Test_Gas_Year() {
+	then.AssertThat(s.T(), germany.GasYear(time.Date(2022, 10, 1, 3, 59, 0, 0, time.UTC)), is.EqualTo(2021))
+	then.AssertThat(s.T(), germany.GasYear(time.Date(2022, 10, 1, 4, 0, 0, 0, time.UTC)), is.EqualTo(2022))
+	then.AssertThat(s.T(), germany.GasYear(time.Date(2023, 9, 30, 12, 0, 0, 0, time.UTC)), is.EqualTo(2022))
+	then.AssertThat(s.T(), germany.StartOfGasYear(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2022, 10, 1, 4, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), germany.StartOfGasYear(time.Date(2022, 9, 30, 12, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2021, 10, 1, 4, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), germany.StartOfNextGasYear(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2023, 10, 1, 4, 0, 0, 0, time.UTC)))
+	// in 1995 CEST ended on 24 September
+	then.AssertThat(s.T(), germany.StartOfGasYear(time.Date(1996, 1, 1, 0, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(1995, 10, 1, 5, 0, 0, 0, time.UTC)))
+}
+
+// Test_Gas_Days_Of_Local_Day tests the mapping of a local day to its overlapping gas days on the day on which CEST starts.
+func (s *Suite) Test_Gas_Days_Of_Local_Day() {
+	gasDays := germany.GasDaysOfLocalDay(time.Date(2022, 3, 27, 12, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), gasDays, is.EqualTo([]local_days.Interval{
+		{Start: time.Date(2022, 3, 26, 5, 0, 0, 0, time.UTC), End: time.Date(2022, 3, 27, 4, 0, 0, 0, time.UTC)},
+		{Start: time.Date(2022, 3, 27, 4, 0, 0, 0, time.UTC), End: time.Date(2022, 3, 28, 4, 0, 0, 0, time.UTC)},
+	}))
+}
+
+// Test_Local_Days_Of_Gas_Day tests the mapping of a gas day to its overlapping local days on the day on which CEST ends.
+func (s *Suite) Test_Local_Days_Of_Gas_Day() {
+	localDays := germany.LocalDaysOfGasDay(time.Date(2022, 10, 30, 2, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), localDays, is.EqualTo([]local_days.Interval{
+		{Start: time.Date(2022, 10, 28, 22, 0, 0, 0, time.UTC), End: time.Date(2022, 10, 29, 22, 0, 0, 0, time.UTC)},
+		{Start: time.Date(2022, 10, 29, 22, 0, 0, 0, time.UTC), End: time.Date(2022, 10, 30, 23, 0, 0, 0, time.UTC)},
+	}))
+}
+
+/***************
+ Holidays
+***************/
+
+// Test_Easter_Sunday tests the date of Easter Sunday for some years.
+func (s *Suite) Test_Easter_Sunday() {
+	for year, expected := range map[int]time.Time{
+		2019: time.Date(2019, 4, 21, 0, 0, 0, 0, time.UTC),
+		2022: time.Date(2022, 4, 17, 0, 0, 0, 0, time.UTC),
+		2024: time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+		2038: time.Date(2038, 4, 25, 0, 0, 0, 0, time.UTC),
+	} {
+		month, day := germany.EasterSunday(year)
+		then.AssertThat(s.T(), time.Date(year, month, day, 0, 0, 0, 0, time.UTC), is.EqualTo(expected))
+	}
+}
+
+// Test_National_Holidays tests that there are 9 national holidays per year (10 in 2017) and that they are available as holiday region "DE".
+func (s *Suite) Test_National_Holidays() {
+	for year, expected := range map[int]int{2022: 9, 2017: 10, 2024: 9} {
+		holidays := 0
+		for day := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC); day.Year() == year; day = day.AddDate(0, 0, 1) {
+			if germany.NationalHolidays.IsHoliday(day.Date()) {
+				holidays++
+			}
+		}
+		then.AssertThat(s.T(), holidays, is.EqualTo(expected))
+	}
+	then.AssertThat(s.T(), germany.NationalHolidays.IsHoliday(2022, time.May, 26), is.True())   // Christi Himmelfahrt
+	then.AssertThat(s.T(), germany.NationalHolidays.IsHoliday(2022, time.June, 16), is.False()) // Fronleichnam is not a national holiday
+	calculator, err := local_days.CalculatorSpec{ZoneName: "Europe/Berlin", HolidayRegion: germany.NationalHolidaysRegion}.Build()
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), calculator.(local_days.BusinessDaysCalculator).IsLocalHoliday(time.Date(2022, 10, 2, 22, 0, 0, 0, time.UTC)), is.True())
+}
+
+/***************
+ SLP Day Classification
+***************/
+
+// Test_SLP_Day_Types tests the day types of the standard load profiles including holidays, 24.12. and 31.12.
+func (s *Suite) Test_SLP_Day_Types() {
+	for _, testCase := range []struct {
+		timestamp time.Time
+		expected  germany.SLPDayType
+	}{
+		{time.Date(2022, 11, 16, 12, 0, 0, 0, time.UTC), germany.SLPWorkingDay},      // Wednesday
+		{time.Date(2022, 11, 19, 12, 0, 0, 0, time.UTC), germany.SLPSaturday},        // Saturday
+		{time.Date(2022, 11, 20, 12, 0, 0, 0, time.UTC), germany.SLPSundayOrHoliday}, // Sunday
+		{time.Date(2022, 10, 2, 22, 0, 0, 0, time.UTC), germany.SLPSundayOrHoliday},  // 2022-10-03 00:00 CEST is a holiday on a Monday
+		{time.Date(2022, 10, 2, 21, 59, 0, 0, time.UTC), germany.SLPSundayOrHoliday}, // 2022-10-02 is a Sunday
+		{time.Date(2022, 10, 3, 22, 0, 0, 0, time.UTC), germany.SLPWorkingDay},       // 2022-10-04 is a Tuesday
+		{time.Date(2023, 12, 24, 12, 0, 0, 0, time.UTC), germany.SLPSundayOrHoliday}, // 24.12. on a Sunday
+		{time.Date(2024, 12, 24, 12, 0, 0, 0, time.UTC), germany.SLPSaturday},        // 24.12. on a Tuesday
+		{time.Date(2024, 12, 30, 23, 0, 0, 0, time.UTC), germany.SLPSaturday},        // 31.12. on a Tuesday
+	} {
+		then.AssertThat(s.T(), germany.ClassifySLPDay(testCase.timestamp).DayType, is.EqualTo(testCase.expected))
+	}
+}
+
+// Test_SLP_Seasons tests the boundaries of the seasons of the standard load profiles in German local time.
+func (s *Suite) Test_SLP_Seasons() {
+	for _, testCase := range []struct {
+		timestamp time.Time
+		expected  germany.SLPSeason
+	}{
+		{time.Date(2022, 3, 20, 22, 59, 0, 0, time.UTC), germany.SLPWinter},    // 2022-03-20 23:59 CET
+		{time.Date(2022, 3, 20, 23, 0, 0, 0, time.UTC), germany.SLPTransition}, // 2022-03-21 00:00 CET
+		{time.Date(2022, 5, 14, 21, 59, 0, 0, time.UTC), germany.SLPTransition},
+		{time.Date(2022, 5, 14, 22, 0, 0, 0, time.UTC), germany.SLPSummer},
+		{time.Date(2022, 9, 14, 21, 59, 0, 0, time.UTC), germany.SLPSummer},
+		{time.Date(2022, 9, 14, 22, 0, 0, 0, time.UTC), germany.SLPTransition},
+		{time.Date(2022, 10, 31, 22, 59, 0, 0, time.UTC), germany.SLPTransition},
+		{time.Date(2022, 10, 31, 23, 0, 0, 0, time.UTC), germany.SLPWinter},
+	} {
+		then.AssertThat(s.T(), germany.ClassifySLPDay(testCase.timestamp).Season, is.EqualTo(testCase.expected))
+	}
+}
+
+// Test_SLP_Classifier_With_Regional_Holidays tests that the classifier uses the given holidays.
+func (s *Suite) Test_SLP_Classifier_With_Regional_Holidays() {
+	corpusChristi := local_days.HolidayCalendarFunc(func(year int, month time.Month, day int) bool {
+		return germany.NationalHolidays.IsHoliday(year, month, day) || (year == 2022 && month == time.June && day == 16)
+	})
+	classifier := germany.NewSLPClassifier(germany.NewGermanLocalDaysCalculator(), corpusChristi)
+	then.AssertThat(s.T(), classifier.Classify(time.Date(2022, 6, 16, 12, 0, 0, 0, time.UTC)), is.EqualTo(germany.SLPDay{DayType: germany.SLPSundayOrHoliday, Season: germany.SLPSummer}))
+	then.AssertThat(s.T(), germany.ClassifySLPDay(time.Date(2022, 6, 16, 12, 0, 0, 0, time.UTC)), is.EqualTo(germany.SLPDay{DayType: germany.SLPWorkingDay, Season: germany.SLPSummer}))
+	then.AssertThat(s.T(), germany.SLPSundayOrHoliday.String(), is.EqualTo("Sonntag/Feiertag"))
+}
+
+/***************
+ Tariff Windows
+***************/
+
+const module3Model = `{
+	"defaultTariff": "ST",
+	"windows": [
+		{"tariff": "NT", "from": "00:00", "to": "06:00", "months": [1, 2, 3, 10, 11, 12]},
+		{"tariff": "HT", "from": "17:00", "to": "20:00", "months": [1, 2, 3, 10, 11, 12], "weekdays": ["Monday", "Tuesday", "Wednesday", "Thursday", "Friday"]}
+	]
+}`
+
+func (s *Suite) tariffCalendar(modelJSON string) germany.TariffCalendar {
+	model, err := germany.ParseTariffModel([]byte(modelJSON))
+	then.AssertThat(s.T(), err, is.Nil())
+	calendar, err := model.Build(germany.NewGermanLocalDaysCalculator())
+	then.AssertThat(s.T(), err, is.Nil())
+	return calendar
+}
+
+// Test_Tariff_Classification tests that timestamps are classified by the local clock time, weekday and month.
+func (s *Suite) Test_Tariff_Classification() {
+	calendar := s.tariffCalendar(module3Model)
+	then.AssertThat(s.T(), calendar.Tariff(time.Date(2023, 1, 16, 23, 0, 0, 0, time.UTC)), is.EqualTo("NT")) // Tuesday 00:00 CET
+	then.AssertThat(s.T(), calendar.Tariff(time.Date(2023, 1, 17, 5, 0, 0, 0, time.UTC)), is.EqualTo("ST"))
+	then.AssertThat(s.T(), calendar.Tariff(time.Date(2023, 1, 17, 16, 0, 0, 0, time.UTC)), is.EqualTo("HT"))
+	then.AssertThat(s.T(), calendar.Tariff(time.Date(2023, 1, 21, 16, 0, 0, 0, time.UTC)), is.EqualTo("ST")) // Saturday
+	then.AssertThat(s.T(), calendar.Tariff(time.Date(2023, 5, 16, 2, 0, 0, 0, time.UTC)), is.EqualTo("ST"))  // no windows in the second quarter
+}
+
+// Test_Tariff_Split tests the split of a local day into tariff segments.
+func (s *Suite) Test_Tariff_Split() {
+	calendar := s.tariffCalendar(module3Model)
+	segments := calendar.Split(time.Date(2023, 1, 16, 23, 0, 0, 0, time.UTC), time.Date(2023, 1, 17, 23, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), segments, is.EqualTo([]germany.TariffSegment{
+		{Interval: local_days.Interval{Start: time.Date(2023, 1, 16, 23, 0, 0, 0, time.UTC), End: time.Date(2023, 1, 17, 5, 0, 0, 0, time.UTC)}, Tariff: "NT"},
+		{Interval: local_days.Interval{Start: time.Date(2023, 1, 17, 5, 0, 0, 0, time.UTC), End: time.Date(2023, 1, 17, 16, 0, 0, 0, time.UTC)}, Tariff: "ST"},
+		{Interval: local_days.Interval{Start: time.Date(2023, 1, 17, 16, 0, 0, 0, time.UTC), End: time.Date(2023, 1, 17, 19, 0, 0, 0, time.UTC)}, Tariff: "HT"},
+		{Interval: local_days.Interval{Start: time.Date(2023, 1, 17, 19, 0, 0, 0, time.UTC), End: time.Date(2023, 1, 17, 23, 0, 0, 0, time.UTC)}, Tariff: "ST"},
+	}))
+	then.AssertThat(s.T(), calendar.Split(time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)), is.EqualTo([]germany.TariffSegment{
+		{Interval: local_days.Interval{Start: time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)}, Tariff: "ST"},
+	}))
+	then.AssertThat(s.T(), calendar.Split(time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)), is.Empty())
+}
+
+// Test_Tariff_Split_CET_To_CEST_Transition tests that a window starting at a skipped local clock time starts with CEST.
+func (s *Suite) Test_Tariff_Split_CET_To_CEST_Transition() {
+	calendar := s.tariffCalendar(`{"defaultTariff": "ST", "windows": [{"tariff": "NT", "from": "02:30", "to": "03:30"}]}`)
+	segments := calendar.Split(time.Date(2022, 3, 26, 23, 0, 0, 0, time.UTC), time.Date(2022, 3, 27, 22, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), segments, is.EqualTo([]germany.TariffSegment{
+		{Interval: local_days.Interval{Start: time.Date(2022, 3, 26, 23, 0, 0, 0, time.UTC), End: time.Date(2022, 3, 27, 1, 0, 0, 0, time.UTC)}, Tariff: "ST"},
+		{Interval: local_days.Interval{Start: time.Date(2022, 3, 27, 1, 0, 0, 0, time.UTC), End: time.Date(2022, 3, 27, 1, 30, 0, 0, time.UTC)}, Tariff: "NT"},
+		{Interval: local_days.Interval{Start: time.Date(2022, 3, 27, 1, 30, 0, 0, time.UTC), End: time.Date(2022, 3, 27, 22, 0, 0, 0, time.UTC)}, Tariff: "ST"},
+	}))
+}
+
+// Test_Tariff_Split_CEST_To_CET_Transition tests that a window within the repeated hour applies twice.
+func (s *Suite) Test_Tariff_Split_CEST_To_CET_Transition() {
+	calendar := s.tariffCalendar(`{"defaultTariff": "ST", "windows": [{"tariff": "NT", "from": "02:30", "to": "03:00"}]}`)
+	segments := calendar.Split(time.Date(2022, 10, 29, 22, 0, 0, 0, time.UTC), time.Date(2022, 10, 30, 23, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), segments, is.EqualTo([]germany.TariffSegment{
+		{Interval: local_days.Interval{Start: time.Date(2022, 10, 29, 22, 0, 0, 0, time.UTC), End: time.Date(2022, 10, 30, 0, 30, 0, 0, time.UTC)}, Tariff: "ST"},
+		{Interval: local_days.Interval{Start: time.Date(2022, 10, 30, 0, 30, 0, 0, time.UTC), End: time.Date(2022, 10, 30, 1, 0, 0, 0, time.UTC)}, Tariff: "NT"},
+		{Interval: local_days.Interval{Start: time.Date(2022, 10, 30, 1, 0, 0, 0, time.UTC), End: time.Date(2022, 10, 30, 1, 30, 0, 0, time.UTC)}, Tariff: "ST"},
+		{Interval: local_days.Interval{Start: time.Date(2022, 10, 30, 1, 30, 0, 0, time.UTC), End: time.Date(2022, 10, 30, 2, 0, 0, 0, time.UTC)}, Tariff: "NT"},
+		{Interval: local_days.Interval{Start: time.Date(2022, 10, 30, 2, 0, 0, 0, time.UTC), End: time.Date(2022, 10, 30, 23, 0, 0, 0, time.UTC)}, Tariff: "ST"},
+	}))
+}
+
+// Test_Tariff_Window_Spanning_Midnight tests a window whose end is before its start.
+func (s *Suite) Test_Tariff_Window_Spanning_Midnight() {
+	calendar := s.tariffCalendar(`{"defaultTariff": "HT", "windows": [{"tariff": "NT", "from": "22:00", "to": "06:00", "validFrom": "2023-01-02"}]}`)
+	segments := calendar.Split(time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC), time.Date(2023, 1, 2, 23, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), segments, is.EqualTo([]germany.TariffSegment{
+		{Interval: local_days.Interval{Start: time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC), End: time.Date(2023, 1, 1, 23, 0, 0, 0, time.UTC)}, Tariff: "HT"},
+		{Interval: local_days.Interval{Start: time.Date(2023, 1, 1, 23, 0, 0, 0, time.UTC), End: time.Date(2023, 1, 2, 5, 0, 0, 0, time.UTC)}, Tariff: "NT"},
+		{Interval: local_days.Interval{Start: time.Date(2023, 1, 2, 5, 0, 0, 0, time.UTC), End: time.Date(2023, 1, 2, 21, 0, 0, 0, time.UTC)}, Tariff: "HT"},
+		{Interval: local_days.Interval{Start: time.Date(2023, 1, 2, 21, 0, 0, 0, time.UTC), End: time.Date(2023, 1, 2, 23, 0, 0, 0, time.UTC)}, Tariff: "NT"},
+	}))
+}
+
+// Test_Invalid_Tariff_Model tests that invalid fields are reported with their JSON path.
+func (s *Suite) Test_Invalid_Tariff_Model() {
+	_, err := germany.ParseTariffModel([]byte(`{"defaultTariff": "ST", "windows": [{"tariff": "NT", "from": "00:00", "to": "24:00"}, {"tariff": "HT", "from": "25:00", "to": "06:00"}]}`))
+	var modelError *germany.TariffModelError
+	then.AssertThat(s.T(), errors.As(err, &modelError), is.True())
+	then.AssertThat(s.T(), modelError.Field, is.EqualTo("windows[1].from"))
+	for _, invalidModel := range []germany.TariffModel{
+		{},
+		{DefaultTariff: "ST", Windows: []germany.TariffWindow{{Tariff: "NT", From: "06:00", To: "06:00"}}},
+		{DefaultTariff: "ST", Windows: []germany.TariffWindow{{Tariff: "NT", From: "06:00", To: "07:00", Weekdays: []string{"Montag"}}}},
+		{DefaultTariff: "ST", Windows: []germany.TariffWindow{{Tariff: "NT", From: "06:00", To: "07:00", Months: []int{13}}}},
+		{DefaultTariff: "ST", Windows: []germany.TariffWindow{{Tariff: "NT", From: "06:00", To: "07:00", ValidFrom: "2023-02-01", ValidUntil: "2023-01-31"}}},
+	} {
+		then.AssertThat(s.T(), invalidModel.Validate(), is.Not(is.Nil()))
+	}
+}
+
+// Test_Tariff_Calculator_Without_Location tests that calculators whose local clock times are unknown are rejected.
+func (s *Suite) Test_Tariff_Calculator_Without_Location() {
+	model, err := germany.ParseTariffModel([]byte(module3Model))
+	then.AssertThat(s.T(), err, is.Nil())
+	_, err = model.Build(local_days.NewConverterBasedLocalDaysCalculator(cetConverter{}))
+	then.AssertThat(s.T(), errors.Is(err, local_days.ErrInvalidArgument), is.True())
+}
+
+// cetConverter converts to CET all year without implementing local_days.LocationProvider, so its local clock times are unknown to the package.
+type cetConverter struct{}
+
+func (cetConverter) ToLocalTime(timestamp time.Time) time.Time {
+	return timestamp.In(time.FixedZone("CET", 60*60))
+}
+
+/***************
+ Spot Products
+***************/
+
+// Test_Gate_Closure tests that the day-ahead gate closure is at 12:00 German local time on the day before the delivery day.
+func (s *Suite) Test_Gate_Closure() {
+	spot := germany.NewGermanSpotCalendar()
+	then.AssertThat(s.T(), spot.GateClosure(time.Date(2022, 11, 16, 12, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2022, 11, 15, 11, 0, 0, 0, time.UTC)))
+	// the delivery day 2022-10-31 has its gate closure on the 25h day, the delivery day 2022-03-28 on the 23h day
+	then.AssertThat(s.T(), spot.GateClosure(time.Date(2022, 10, 31, 12, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2022, 10, 30, 11, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), spot.GateClosure(time.Date(2022, 3, 28, 12, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2022, 3, 27, 10, 0, 0, 0, time.UTC)))
+}
+
+// Test_Day_Ahead_Delivery_Day tests that the delivery day of the next day-ahead auction changes at gate closure.
+func (s *Suite) Test_Day_Ahead_Delivery_Day() {
+	spot := germany.NewGermanSpotCalendar()
+	then.AssertThat(s.T(), spot.DayAheadDeliveryDay(time.Date(2022, 11, 15, 10, 59, 0, 0, time.UTC)), is.EqualTo(local_days.Interval{Start: time.Date(2022, 11, 15, 23, 0, 0, 0, time.UTC), End: time.Date(2022, 11, 16, 23, 0, 0, 0, time.UTC)}))
+	then.AssertThat(s.T(), spot.DayAheadDeliveryDay(time.Date(2022, 11, 15, 11, 0, 0, 0, time.UTC)), is.EqualTo(local_days.Interval{Start: time.Date(2022, 11, 16, 23, 0, 0, 0, time.UTC), End: time.Date(2022, 11, 17, 23, 0, 0, 0, time.UTC)}))
+	then.AssertThat(s.T(), spot.DayAheadDeliveryDay(time.Date(2022, 10, 29, 9, 0, 0, 0, time.UTC)), is.EqualTo(local_days.Interval{Start: time.Date(2022, 10, 29, 22, 0, 0, 0, time.UTC), End: time.Date(2022, 10, 30, 23, 0, 0, 0, time.UTC)}))
+}
+
+// Test_Hourly_Products tests the hourly products of normal days and of the days of the DST transitions.
+func (s *Suite) Test_Hourly_Products() {
+	spot := germany.NewGermanSpotCalendar()
+	products := spot.HourlyProducts(time.Date(2022, 11, 16, 12, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), len(products), is.EqualTo(24))
+	then.AssertThat(s.T(), products[0], is.EqualTo(germany.SpotProduct{Interval: local_days.Interval{Start: time.Date(2022, 11, 15, 23, 0, 0, 0, time.UTC), End: time.Date(2022, 11, 16, 0, 0, 0, 0, time.UTC)}, DeliveryDate: time.Date(2022, 11, 16, 0, 0, 0, 0, time.UTC), ID: "H01"}))
+	then.AssertThat(s.T(), products[23].ID, is.EqualTo("H24"))
+	products = spot.HourlyProducts(time.Date(2022, 3, 27, 12, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), len(products), is.EqualTo(23))
+	then.AssertThat(s.T(), []string{products[1].ID, products[2].ID}, is.EqualTo([]string{"H02", "H04"}))
+	products = spot.HourlyProducts(time.Date(2022, 10, 30, 12, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), len(products), is.EqualTo(25))
+	then.AssertThat(s.T(), []string{products[1].ID, products[2].ID, products[3].ID, products[4].ID}, is.EqualTo([]string{"H02", "H03A", "H03B", "H04"}))
+	then.AssertThat(s.T(), products[3].Start, is.EqualTo(time.Date(2022, 10, 30, 1, 0, 0, 0, time.UTC)))
+}
+
+// Test_Quarter_Hourly_Products tests the number and the IDs of quarter-hourly products.
+func (s *Suite) Test_Quarter_Hourly_Products() {
+	spot := germany.NewGermanSpotCalendar()
+	then.AssertThat(s.T(), len(spot.QuarterHourlyProducts(time.Date(2022, 11, 16, 12, 0, 0, 0, time.UTC))), is.EqualTo(96))
+	then.AssertThat(s.T(), len(spot.QuarterHourlyProducts(time.Date(2022, 3, 27, 12, 0, 0, 0, time.UTC))), is.EqualTo(92))
+	products := spot.QuarterHourlyProducts(time.Date(2022, 10, 30, 12, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), len(products), is.EqualTo(100))
+	then.AssertThat(s.T(), []string{products[8].ID, products[11].ID, products[12].ID, products[15].ID, products[16].ID}, is.EqualTo([]string{"Q09A", "Q12A", "Q09B", "Q12B", "Q13"}))
+	then.AssertThat(s.T(), products[99].ID, is.EqualTo("Q96"))
+}
+
+// Test_Spot_Calculator_Without_Location tests that calculators whose local clock times are unknown are rejected.
+func (s *Suite) Test_Spot_Calculator_Without_Location() {
+	_, err := germany.NewSpotCalendar(local_days.NewConverterBasedLocalDaysCalculator(cetConverter{}))
+	then.AssertThat(s.T(), errors.Is(err, local_days.ErrInvalidArgument), is.True())
+}
+
+/***************
+ Futures Delivery Periods
+***************/
+
+// Test_Futures_Delivery_Periods tests the boundaries of months, quarters, seasons and years in German local time.
+func (s *Suite) Test_Futures_Delivery_Periods() {
+	futures := germany.NewGermanFuturesCalendar()
+	then.AssertThat(s.T(), futures.Month(2023, time.March), is.EqualTo(local_days.Interval{Start: time.Date(2023, 2, 28, 23, 0, 0, 0, time.UTC), End: time.Date(2023, 3, 31, 22, 0, 0, 0, time.UTC)}))
+	then.AssertThat(s.T(), futures.Quarter(2023, 4), is.EqualTo(local_days.Interval{Start: time.Date(2023, 9, 30, 22, 0, 0, 0, time.UTC), End: time.Date(2023, 12, 31, 23, 0, 0, 0, time.UTC)}))
+	then.AssertThat(s.T(), futures.Season(2023, germany.WinterSeason), is.EqualTo(local_days.Interval{Start: time.Date(2023, 9, 30, 22, 0, 0, 0, time.UTC), End: time.Date(2024, 3, 31, 22, 0, 0, 0, time.UTC)}))
+	then.AssertThat(s.T(), futures.Season(2023, germany.SummerSeason), is.EqualTo(local_days.Interval{Start: time.Date(2023, 3, 31, 22, 0, 0, 0, time.UTC), End: time.Date(2023, 9, 30, 22, 0, 0, 0, time.UTC)}))
+	then.AssertThat(s.T(), futures.Year(2023), is.EqualTo(local_days.Interval{Start: time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC), End: time.Date(2023, 12, 31, 23, 0, 0, 0, time.UTC)}))
+	s.Panics(func() { futures.Quarter(2023, 5) })
+}
+
+// Test_Futures_Base_Hours tests that the base hours reflect the DST transitions.
+func (s *Suite) Test_Futures_Base_Hours() {
+	futures := germany.NewGermanFuturesCalendar()
+	then.AssertThat(s.T(), futures.BaseHours(futures.Month(2023, time.March)), is.EqualTo(743))
+	then.AssertThat(s.T(), futures.BaseHours(futures.Month(2023, time.October)), is.EqualTo(745))
+	then.AssertThat(s.T(), futures.BaseHours(futures.Quarter(2023, 1)), is.EqualTo(2159))
+	then.AssertThat(s.T(), futures.BaseHours(futures.Season(2023, germany.WinterSeason)), is.EqualTo(4392))
+	then.AssertThat(s.T(), futures.BaseHours(futures.Year(2023)), is.EqualTo(8760))
+	then.AssertThat(s.T(), futures.BaseHours(futures.Year(2024)), is.EqualTo(8784))
+}
+
+// Test_Futures_Peak_Hours tests the German peak hours and a custom peak block.
+func (s *Suite) Test_Futures_Peak_Hours() {
+	futures := germany.NewGermanFuturesCalendar()
+	march := futures.Month(2023, time.March)
+	// March 2023 has 23 weekdays
+	then.AssertThat(s.T(), futures.PeakHours(march, germany.PeakBlock), is.EqualTo(276))
+	then.AssertThat(s.T(), futures.OffPeakHours(march, germany.PeakBlock), is.EqualTo(467))
+	then.AssertThat(s.T(), futures.PeakHours(futures.Year(2023), germany.PeakBlock), is.EqualTo(3120))
+	peak := futures.Peak(march, germany.PeakBlock)
+	then.AssertThat(s.T(), peak[0], is.EqualTo(local_days.Interval{Start: time.Date(2023, 3, 1, 7, 0, 0, 0, time.UTC), End: time.Date(2023, 3, 1, 8, 0, 0, 0, time.UTC)}))
+	// on 2023-03-31 (CEST) the peak hours start at 06:00 UTC
+	then.AssertThat(s.T(), peak[len(peak)-1], is.EqualTo(local_days.Interval{Start: time.Date(2023, 3, 31, 17, 0, 0, 0, time.UTC), End: time.Date(2023, 3, 31, 18, 0, 0, 0, time.UTC)}))
+	then.AssertThat(s.T(), len(futures.OffPeak(march, germany.PeakBlock)), is.EqualTo(467))
+	everyDay, err := germany.ParseBlock("Peak Mo-Su 08-20")
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), futures.PeakHours(march, everyDay), is.EqualTo(31*12))
+	// the off-peak hours of the peak block are the hours of the off-peak block
+	then.AssertThat(s.T(), futures.OffPeak(march, germany.PeakBlock), is.EqualTo(futures.Peak(march, germany.OffPeakBlock)))
+}
+
+// Test_Futures_Calculator_Without_Location tests that calculators whose local clock times are unknown are rejected.
+func (s *Suite) Test_Futures_Calculator_Without_Location() {
+	_, err := germany.NewFuturesCalendar(local_days.NewConverterBasedLocalDaysCalculator(cetConverter{}))
+	then.AssertThat(s.T(), errors.Is(err, local_days.ErrInvalidArgument), is.True())
+}
+
+/***************
+ Block Products
+***************/
+
+// Test_Parse_Block tests the compact block notation and the predefined blocks.
+func (s *Suite) Test_Parse_Block() {
+	block, err := germany.ParseBlock("Peak Mo–Fr 08-20")
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), block.Name, is.EqualTo("Peak"))
+	then.AssertThat(s.T(), block.Windows, is.EqualTo(germany.PeakBlock.Windows))
+	block, err = germany.ParseBlock("Off-Peak 1 00-08")
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), block.Name, is.EqualTo("Off-Peak 1"))
+	then.AssertThat(s.T(), block.Windows, is.EqualTo([]germany.BlockWindow{{Weekdays: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}, From: 0, To: 8 * time.Hour}}))
+	block, err = germany.ParseBlock("Weekend Sa-Mo 00-06:30, Su 12-24")
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), block.Windows[0], is.EqualTo(germany.BlockWindow{Weekdays: []time.Weekday{time.Saturday, time.Sunday, time.Monday}, From: 0, To: 6*time.Hour + 30*time.Minute}))
+	then.AssertThat(s.T(), block.String(), is.EqualTo("Weekend Mo 00-06:30, Sa-Su 00-06:30, Su 12-24"))
+	block, err = germany.ParseBlock("off-peak")
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), block.String(), is.EqualTo("Off-Peak Mo-Fr 00-08, Mo-Fr 20-24, Sa-Su 00-24"))
+	for _, invalid := range []string{"", "Shoulder", "Peak Mo-Fr 20-08", "Peak Mo-Xy 08-20", "Peak Mo-Fr 08-25", "Peak 08-20, Fr"} {
+		_, err = germany.ParseBlock(invalid)
+		then.AssertThat(s.T(), err, is.Not(is.Nil()))
+	}
+}
+
+// Test_Expand_Peak_Block tests that the peak block of a month has as many hours as counted by the futures calendar.
+func (s *Suite) Test_Expand_Peak_Block() {
+	march := germany.NewGermanFuturesCalendar().Month(2023, time.March)
+	intervals, err := germany.PeakBlock.Expand(germany.NewGermanLocalDaysCalculator(), march)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), len(intervals), is.EqualTo(23))
+	var total time.Duration
+	for _, interval := range intervals {
+		total += interval.Duration()
+	}
+	then.AssertThat(s.T(), total, is.EqualTo(276*time.Hour))
+	then.AssertThat(s.T(), intervals[22], is.EqualTo(local_days.Interval{Start: time.Date(2023, 3, 31, 6, 0, 0, 0, time.UTC), End: time.Date(2023, 3, 31, 18, 0, 0, 0, time.UTC)}))
+	base, err := germany.BaseBlock.Expand(germany.NewGermanLocalDaysCalculator(), march)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), base, is.EqualTo([]local_days.Interval{march}))
+}
+
+// Test_Expand_Block_DST_Transitions tests that blocks containing the skipped hour are shorter and blocks containing the repeated hour are longer.
+func (s *Suite) Test_Expand_Block_DST_Transitions() {
+	calculator := germany.NewGermanLocalDaysCalculator()
+	block, err := germany.ParseBlock("Night 01-03")
+	then.AssertThat(s.T(), err, is.Nil())
+	intervals, err := block.Expand(calculator, local_days.Interval{Start: time.Date(2022, 3, 26, 23, 0, 0, 0, time.UTC), End: time.Date(2022, 3, 27, 22, 0, 0, 0, time.UTC)})
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), intervals, is.EqualTo([]local_days.Interval{{Start: time.Date(2022, 3, 27, 0, 0, 0, 0, time.UTC), End: time.Date(2022, 3, 27, 1, 0, 0, 0, time.UTC)}}))
+	intervals, err = block.Expand(calculator, local_days.Interval{Start: time.Date(2022, 10, 29, 22, 0, 0, 0, time.UTC), End: time.Date(2022, 10, 30, 23, 0, 0, 0, time.UTC)})
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), intervals, is.EqualTo([]local_days.Interval{{Start: time.Date(2022, 10, 29, 23, 0, 0, 0, time.UTC), End: time.Date(2022, 10, 30, 2, 0, 0, 0, time.UTC)}}))
+	// a window starting within the skipped hour starts at the transition
+	block, err = germany.ParseBlock("02:30-04")
+	then.AssertThat(s.T(), err, is.Nil())
+	intervals, err = block.Expand(calculator, local_days.Interval{Start: time.Date(2022, 3, 26, 23, 0, 0, 0, time.UTC), End: time.Date(2022, 3, 27, 22, 0, 0, 0, time.UTC)})
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), intervals, is.EqualTo([]local_days.Interval{{Start: time.Date(2022, 3, 27, 1, 0, 0, 0, time.UTC), End: time.Date(2022, 3, 27, 2, 0, 0, 0, time.UTC)}}))
+}
+
+// Test_Expand_Block_Calculator_Without_Location tests that calculators whose local clock times are unknown are rejected.
+func (s *Suite) Test_Expand_Block_Calculator_Without_Location() {
+	_, err := germany.PeakBlock.Expand(local_days.NewConverterBasedLocalDaysCalculator(cetConverter{}), local_days.Interval{Start: time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2023, 3, 2, 0, 0, 0, 0, time.UTC)})
+	then.AssertThat(s.T(), errors.Is(err, local_days.ErrInvalidArgument), is.True())
+}
+
 // ----------------------------
 // test framework boiler plate
 // ---------------------------
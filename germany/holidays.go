@@ -0,0 +1,64 @@
+package germany
+
+import (
+	"time"
+
+	"github.com/hochfrequenz/go-local-days/local_days"
+)
+
+// NationalHolidaysRegion is the holiday region under which NationalHolidays are registered (see local_days.RegisterHolidayRegion), e.g. for local_days.CalculatorSpec{ZoneName: "Europe/Berlin", HolidayRegion: "DE"}.
+const NationalHolidaysRegion = "DE"
+
+// NationalHolidays are the German national holidays (bundeseinheitliche Feiertage) that are observed in all federal states. Holidays of single federal states are not included.
+var NationalHolidays local_days.HolidayCalendar = local_days.HolidayCalendarFunc(isNationalHoliday)
+
+func init() {
+	_ = local_days.RegisterHolidayRegion(NationalHolidaysRegion, NationalHolidays)
+}
+
+func isNationalHoliday(year int, month time.Month, day int) bool {
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	easterMonth, easterDay := EasterSunday(year)
+	daysAfterEaster := int(date.Sub(time.Date(year, easterMonth, easterDay, 0, 0, 0, 0, time.UTC)) / (24 * time.Hour))
+	switch {
+	case month == time.January && day == 1: // Neujahr
+		return true
+	case daysAfterEaster == -2 || daysAfterEaster == 1: // Karfreitag, Ostermontag
+		return true
+	case month == time.May && day == 1: // Tag der Arbeit
+		return true
+	case daysAfterEaster == 39 || daysAfterEaster == 50: // Christi Himmelfahrt, Pfingstmontag
+		return true
+	case month == time.June && day == 17: // Tag der deutschen Einheit until 1990
+		return year >= 1954 && year <= 1990
+	case month == time.October && day == 3: // Tag der Deutschen Einheit since 1990
+		return year >= 1990
+	case month == time.October && day == 31: // Reformationstag, only in 2017 in all federal states
+		return year == 2017
+	case month == time.November && day >= 16 && day <= 22 && date.Weekday() == time.Wednesday: // Buß- und Bettag until 1994
+		return year <= 1994
+	case month == time.December && (day == 25 || day == 26): // Weihnachtstage
+		return true
+	}
+	return false
+}
+
+// EasterSunday returns the date of Easter Sunday in the given year (according to the Gregorian calendar).
+func EasterSunday(year int) (time.Month, int) {
+	// anonymous Gregorian algorithm (Meeus/Jones/Butcher)
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Month(month), day
+}
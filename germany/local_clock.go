@@ -0,0 +1,17 @@
+package germany
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hochfrequenz/go-local-days/local_days"
+)
+
+// locationOf returns the location of calculator, which is needed to find the points in time of local clock times. It returns an *local_days.InvalidArgumentError for function if calculator is not based on a time.Location (see local_days.Location).
+func locationOf(function string, calculator local_days.LocalDaysCalculator) (*time.Location, error) {
+	location := local_days.Location(calculator)
+	if location == nil {
+		return nil, &local_days.InvalidArgumentError{Function: function, Argument: "calculator", Value: fmt.Sprintf("%T", calculator), Err: fmt.Errorf("the local clock times of a calculator without location are unknown")}
+	}
+	return location, nil
+}
@@ -0,0 +1,114 @@
+package germany
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hochfrequenz/go-local-days/local_days"
+)
+
+// SLPDayType is the type of a local day in the BDEW standard load profiles (Standardlastprofile).
+type SLPDayType int
+
+const (
+	// SLPWorkingDay is a working day (Werktag), i.e. Monday to Friday unless it's a holiday, 24.12. or 31.12.
+	SLPWorkingDay SLPDayType = iota + 1
+	// SLPSaturday is a Saturday (Samstag). 24.12. and 31.12. are treated as Saturdays unless they are Sundays.
+	SLPSaturday
+	// SLPSundayOrHoliday is a Sunday or a holiday (Sonntag/Feiertag).
+	SLPSundayOrHoliday
+)
+
+func (t SLPDayType) String() string {
+	switch t {
+	case SLPWorkingDay:
+		return "Werktag"
+	case SLPSaturday:
+		return "Samstag"
+	case SLPSundayOrHoliday:
+		return "Sonntag/Feiertag"
+	}
+	return "unknown"
+}
+
+// SLPSeason is the season of a local day in the BDEW standard load profiles.
+type SLPSeason int
+
+const (
+	// SLPWinter is the winter season (Winter) from 01.11. until 20.03.
+	SLPWinter SLPSeason = iota + 1
+	// SLPSummer is the summer season (Sommer) from 15.05. until 14.09.
+	SLPSummer
+	// SLPTransition is the transition season (Übergangszeit) from 21.03. until 14.05. and from 15.09. until 31.10.
+	SLPTransition
+)
+
+func (s SLPSeason) String() string {
+	switch s {
+	case SLPWinter:
+		return "Winter"
+	case SLPSummer:
+		return "Sommer"
+	case SLPTransition:
+		return "Übergangszeit"
+	}
+	return "unknown"
+}
+
+// SLPDay is the classification of a local day that determines which values of a standard load profile apply.
+type SLPDay struct {
+	DayType SLPDayType
+	Season  SLPSeason
+}
+
+// SLPClassifier classifies local days for the BDEW standard load profiles.
+type SLPClassifier struct {
+	calculator local_days.LocalDaysCalculator
+	holidays   local_days.HolidayCalendar
+}
+
+// NewSLPClassifier returns an SLPClassifier that uses the local days of calculator and the given holidays (e.g. NationalHolidays combined with the holidays of a federal state).
+// It panics if calculator does not know the local dates of its local days (see local_days.LocalDate) or if holidays is nil.
+func NewSLPClassifier(calculator local_days.LocalDaysCalculator, holidays local_days.HolidayCalendar) SLPClassifier {
+	if _, err := local_days.LocalDate(calculator, time.Unix(0, 0).UTC()); err != nil {
+		log.Panic(err)
+	}
+	if holidays == nil {
+		log.Panic(fmt.Errorf("The holidays must not be nil"))
+	}
+	return SLPClassifier{calculator: calculator, holidays: holidays}
+}
+
+// ClassifySLPDay classifies the German local day of timestamp using the NationalHolidays.
+func ClassifySLPDay(timestamp time.Time) SLPDay {
+	return NewSLPClassifier(NewGermanLocalDaysCalculator(), NationalHolidays).Classify(timestamp)
+}
+
+// Classify returns the day type and season of the local day of timestamp.
+func (c SLPClassifier) Classify(timestamp time.Time) SLPDay {
+	date, _ := local_days.LocalDate(c.calculator, timestamp)
+	return SLPDay{DayType: c.dayType(date), Season: slpSeason(date.Month(), date.Day())}
+}
+
+func (c SLPClassifier) dayType(date time.Time) SLPDayType {
+	year, month, day := date.Date()
+	switch {
+	case date.Weekday() == time.Sunday || c.holidays.IsHoliday(year, month, day):
+		return SLPSundayOrHoliday
+	case date.Weekday() == time.Saturday || (month == time.December && (day == 24 || day == 31)):
+		return SLPSaturday
+	}
+	return SLPWorkingDay
+}
+
+func slpSeason(month time.Month, day int) SLPSeason {
+	monthDay := int(month)*100 + day
+	switch {
+	case monthDay >= 1101 || monthDay <= 320:
+		return SLPWinter
+	case monthDay >= 515 && monthDay <= 914:
+		return SLPSummer
+	}
+	return SLPTransition
+}
@@ -0,0 +1,100 @@
+package germany
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hochfrequenz/go-local-days/local_days"
+)
+
+// DayAheadGateClosure is the local clock time on the day before the delivery day at which the day-ahead auction closes (12:00 CET or CEST).
+const DayAheadGateClosure = 12 * time.Hour
+
+// SpotProduct is an hourly or quarter-hourly product of the day-ahead or intraday market.
+type SpotProduct struct {
+	// Interval is the delivery period of the product in UTC
+	local_days.Interval
+	// DeliveryDate is the local date of the delivery day as midnight UTC (see local_days.LocalDate)
+	DeliveryDate time.Time
+	// ID identifies the product within its delivery day by the local clock time: "H01" to "H24" for hours and "Q01" to "Q96" for quarter hours.
+	// The products of the hour that is repeated at the end of DST get the suffixes "A" and "B" (e.g. "H03A" and "H03B"), the products of the hour that is skipped at the start of DST do not exist (e.g. "H03").
+	ID string
+}
+
+// SpotCalendar returns the delivery days, gate closures and products of the day-ahead and intraday markets. Create it with NewSpotCalendar or NewGermanSpotCalendar.
+type SpotCalendar struct {
+	calculator local_days.LocalDaysCalculator
+	location   *time.Location
+}
+
+// NewSpotCalendar returns a SpotCalendar whose delivery days are the local days of calculator. It returns an *local_days.InvalidArgumentError if the calculator is not based on a time.Location (see local_days.Location), because then the local clock times of the products are unknown.
+func NewSpotCalendar(calculator local_days.LocalDaysCalculator) (SpotCalendar, error) {
+	location, err := locationOf("NewSpotCalendar", calculator)
+	if err != nil {
+		return SpotCalendar{}, err
+	}
+	return SpotCalendar{calculator: calculator, location: location}, nil
+}
+
+// NewGermanSpotCalendar returns the SpotCalendar of the German market area, whose delivery days are German local days.
+func NewGermanSpotCalendar() SpotCalendar {
+	calendar, err := NewSpotCalendar(NewGermanLocalDaysCalculator())
+	if err != nil {
+		log.Panic(err)
+	}
+	return calendar
+}
+
+// DeliveryDay returns the delivery day that contains timestamp.
+func (c SpotCalendar) DeliveryDay(timestamp time.Time) local_days.Interval {
+	return local_days.Interval{Start: c.calculator.StartOfLocalDay(timestamp), End: c.calculator.StartOfNextLocalDay(timestamp)}
+}
+
+// GateClosure returns the gate closure of the day-ahead auction for the delivery day that contains deliveryDay, i.e. 12:00 local time on the day before.
+func (c SpotCalendar) GateClosure(deliveryDay time.Time) time.Time {
+	date, _ := local_days.LocalDate(c.calculator, deliveryDay)
+	year, month, day := date.AddDate(0, 0, -1).Date()
+	// the gate closure is a local clock time, so it must not be added to the start of the day (which would be off by an hour on the days of the DST transitions)
+	return time.Date(year, month, day, 0, 0, int(DayAheadGateClosure/time.Second), 0, c.location).UTC()
+}
+
+// DayAheadDeliveryDay returns the delivery day of the next day-ahead auction whose gate closure is after timestamp, e.g. the next local day if timestamp is before 12:00 local time and the local day after the next otherwise.
+func (c SpotCalendar) DayAheadDeliveryDay(timestamp time.Time) local_days.Interval {
+	deliveryDay := c.DeliveryDay(c.calculator.StartOfNextLocalDay(timestamp))
+	if !timestamp.Before(c.GateClosure(deliveryDay.Start)) {
+		deliveryDay = c.DeliveryDay(deliveryDay.End)
+	}
+	return deliveryDay
+}
+
+// HourlyProducts returns the hourly products of the delivery day that contains deliveryDay, i.e. 23, 24 or 25 products in Germany.
+func (c SpotCalendar) HourlyProducts(deliveryDay time.Time) []SpotProduct {
+	return c.products(deliveryDay, time.Hour, "H")
+}
+
+// QuarterHourlyProducts returns the quarter-hourly products of the delivery day that contains deliveryDay, i.e. 92, 96 or 100 products in Germany.
+func (c SpotCalendar) QuarterHourlyProducts(deliveryDay time.Time) []SpotProduct {
+	return c.products(deliveryDay, 15*time.Minute, "Q")
+}
+
+func (c SpotCalendar) products(deliveryDay time.Time, length time.Duration, prefix string) []SpotProduct {
+	day := c.DeliveryDay(deliveryDay)
+	date, _ := local_days.LocalDate(c.calculator, day.Start)
+	var products []SpotProduct
+	// the IDs of the products of a repeated local clock time get the suffixes "A" and "B"
+	firstOccurrences := map[string]int{}
+	for start := day.Start; start.Before(day.End); start = start.Add(length) {
+		local := start.In(c.location)
+		number := (time.Duration(local.Hour())*time.Hour+time.Duration(local.Minute())*time.Minute)/length + 1
+		id := fmt.Sprintf("%s%02d", prefix, number)
+		if first, ok := firstOccurrences[id]; ok {
+			products[first].ID = id + "A"
+			id += "B"
+		} else {
+			firstOccurrences[id] = len(products)
+		}
+		products = append(products, SpotProduct{Interval: local_days.Interval{Start: start, End: start.Add(length)}, DeliveryDate: date, ID: id})
+	}
+	return products
+}
@@ -0,0 +1,297 @@
+package germany
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hochfrequenz/go-local-days/local_days"
+)
+
+// TariffModel is a serializable description of time-of-use tariff windows (e.g. HT/NT or the time windows of §14a EnWG module 3) in local clock time, e.g.:
+//
+//	{"defaultTariff": "ST", "windows": [{"tariff": "NT", "from": "00:00", "to": "06:00", "months": [1, 2, 3]}, {"tariff": "HT", "from": "17:00", "to": "20:00", "months": [1, 2, 3]}]}
+//
+// The first window that matches the local date and clock time of a timestamp determines its tariff, if no window matches, the DefaultTariff applies. Use Build to classify timestamps and to split intervals into tariff segments.
+type TariffModel struct {
+	// DefaultTariff is the tariff outside all windows, e.g. "ST" (Standardtarif)
+	DefaultTariff string `json:"defaultTariff" yaml:"defaultTariff"`
+	// Windows are the tariff windows in the order of their precedence
+	Windows []TariffWindow `json:"windows" yaml:"windows"`
+}
+
+// TariffWindow is a daily recurring local clock time window of a TariffModel. All filters (Weekdays, Months, ValidFrom and ValidUntil) refer to the local date of the clock time and are optional.
+type TariffWindow struct {
+	// Tariff is the tariff within the window, e.g. "HT"
+	Tariff string `json:"tariff" yaml:"tariff"`
+	// From is the local clock time at which the window starts as "hh:mm", e.g. "17:00"
+	From string `json:"from" yaml:"from"`
+	// To is the local clock time at which the window ends as "hh:mm" (exclusive), e.g. "20:00" or "24:00" for the end of the day. If To is before From, the window spans midnight, e.g. "22:00" to "06:00" matches from 00:00 to 06:00 and from 22:00 to 24:00 of every matching day.
+	To string `json:"to" yaml:"to"`
+	// Weekdays are the English names of the weekdays on which the window applies, e.g. ["Monday", "Friday"]. Empty means every weekday.
+	Weekdays []string `json:"weekdays,omitempty" yaml:"weekdays,omitempty"`
+	// Months are the months (1-12) in which the window applies, e.g. [1, 2, 3] for the first quarter. Empty means every month.
+	Months []int `json:"months,omitempty" yaml:"months,omitempty"`
+	// ValidFrom is the first local date on which the window applies as "YYYY-MM-DD". Empty means unbounded.
+	ValidFrom string `json:"validFrom,omitempty" yaml:"validFrom,omitempty"`
+	// ValidUntil is the last local date on which the window applies as "YYYY-MM-DD" (inclusive). Empty means unbounded.
+	ValidUntil string `json:"validUntil,omitempty" yaml:"validUntil,omitempty"`
+}
+
+// TariffModelError is returned by TariffModel.Build and ParseTariffModel if a field of the model is invalid.
+type TariffModelError struct {
+	// Field is the JSON path of the invalid field, e.g. "windows[1].from"
+	Field string
+	// Value is the invalid value
+	Value string
+	// Err describes why the value is invalid
+	Err error
+}
+
+func (e *TariffModelError) Error() string {
+	return fmt.Sprintf("The value '%s' of the tariff model field '%s' is invalid: %v", e.Value, e.Field, e.Err)
+}
+
+func (e *TariffModelError) Unwrap() error {
+	return e.Err
+}
+
+// ParseTariffModel parses a TariffModel from JSON and validates it. It returns a *TariffModelError if a field of the model is invalid.
+func ParseTariffModel(data []byte) (TariffModel, error) {
+	var model TariffModel
+	if err := json.Unmarshal(data, &model); err != nil {
+		return TariffModel{}, err
+	}
+	if err := model.Validate(); err != nil {
+		return TariffModel{}, err
+	}
+	return model, nil
+}
+
+// Validate returns a *TariffModelError for the first invalid field of the model or nil if the model is valid.
+func (m TariffModel) Validate() error {
+	_, err := m.windows()
+	return err
+}
+
+// Build returns the TariffCalendar that applies the model to the local days of calculator (e.g. NewGermanLocalDaysCalculator()).
+// It returns a *TariffModelError if a field of the model is invalid and an *local_days.InvalidArgumentError if the calculator is not based on a time.Location (see local_days.Location), because then the local clock times are unknown.
+func (m TariffModel) Build(calculator local_days.LocalDaysCalculator) (TariffCalendar, error) {
+	windows, err := m.windows()
+	if err != nil {
+		return TariffCalendar{}, err
+	}
+	location, err := locationOf("TariffModel.Build", calculator)
+	if err != nil {
+		return TariffCalendar{}, err
+	}
+	return TariffCalendar{calculator: calculator, location: location, defaultTariff: m.DefaultTariff, windows: windows}, nil
+}
+
+func (m TariffModel) windows() ([]tariffWindow, error) {
+	if m.DefaultTariff == "" {
+		return nil, &TariffModelError{Field: "defaultTariff", Value: m.DefaultTariff, Err: fmt.Errorf("the default tariff must not be empty")}
+	}
+	windows := make([]tariffWindow, 0, len(m.Windows))
+	for i, window := range m.Windows {
+		parsed, err := window.parse(fmt.Sprintf("windows[%d]", i))
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, parsed)
+	}
+	return windows, nil
+}
+
+// tariffWindow is the parsed TariffWindow. Clock times are seconds since local midnight, dates are year*10000+month*100+day.
+type tariffWindow struct {
+	tariff     string
+	from       int
+	to         int
+	weekdays   map[time.Weekday]bool
+	months     map[time.Month]bool
+	validFrom  int
+	validUntil int
+}
+
+func (w TariffWindow) parse(field string) (tariffWindow, error) {
+	parsed := tariffWindow{tariff: w.Tariff}
+	if w.Tariff == "" {
+		return parsed, &TariffModelError{Field: field + ".tariff", Value: w.Tariff, Err: fmt.Errorf("the tariff must not be empty")}
+	}
+	var err error
+	if parsed.from, err = parseTariffClock(w.From); err != nil || parsed.from == local_days.SecondsPerDay {
+		return parsed, &TariffModelError{Field: field + ".from", Value: w.From, Err: fmt.Errorf("expected a local clock time from '00:00' to '23:59'")}
+	}
+	if parsed.to, err = parseTariffClock(w.To); err != nil {
+		return parsed, &TariffModelError{Field: field + ".to", Value: w.To, Err: fmt.Errorf("expected a local clock time from '00:00' to '24:00'")}
+	}
+	if parsed.from == parsed.to {
+		return parsed, &TariffModelError{Field: field + ".to", Value: w.To, Err: fmt.Errorf("the window must not start and end at the same clock time, use '00:00' to '24:00' for whole days")}
+	}
+	if len(w.Weekdays) > 0 {
+		parsed.weekdays = map[time.Weekday]bool{}
+		for j, name := range w.Weekdays {
+			weekday, err := local_days.ParseWeekday(name)
+			if err != nil {
+				return parsed, &TariffModelError{Field: fmt.Sprintf("%s.weekdays[%d]", field, j), Value: name, Err: err}
+			}
+			parsed.weekdays[weekday] = true
+		}
+	}
+	if len(w.Months) > 0 {
+		parsed.months = map[time.Month]bool{}
+		for j, month := range w.Months {
+			if month < 1 || month > 12 {
+				return parsed, &TariffModelError{Field: fmt.Sprintf("%s.months[%d]", field, j), Value: strconv.Itoa(month), Err: fmt.Errorf("expected a month from 1 to 12")}
+			}
+			parsed.months[time.Month(month)] = true
+		}
+	}
+	if parsed.validFrom, err = parseTariffDate(w.ValidFrom); err != nil {
+		return parsed, &TariffModelError{Field: field + ".validFrom", Value: w.ValidFrom, Err: err}
+	}
+	if parsed.validUntil, err = parseTariffDate(w.ValidUntil); err != nil {
+		return parsed, &TariffModelError{Field: field + ".validUntil", Value: w.ValidUntil, Err: err}
+	}
+	if parsed.validFrom != 0 && parsed.validUntil != 0 && parsed.validUntil < parsed.validFrom {
+		return parsed, &TariffModelError{Field: field + ".validUntil", Value: w.ValidUntil, Err: fmt.Errorf("the window ends before it starts")}
+	}
+	return parsed, nil
+}
+
+// parseTariffClock parses "hh:mm" from "00:00" to "24:00" as seconds since midnight.
+func parseTariffClock(value string) (int, error) {
+	if len(value) != 5 || value[2] != ':' || strings.IndexFunc(value[:2]+value[3:], func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+		return 0, fmt.Errorf("expected 'hh:mm'")
+	}
+	hours, _ := strconv.Atoi(value[:2])
+	minutes, _ := strconv.Atoi(value[3:])
+	if minutes > 59 || hours > 24 || (hours == 24 && minutes != 0) {
+		return 0, fmt.Errorf("the clock time is out of range")
+	}
+	return hours*60*60 + minutes*60, nil
+}
+
+// parseTariffDate parses "YYYY-MM-DD" as year*10000+month*100+day. An empty value is 0.
+func parseTariffDate(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return 0, fmt.Errorf("expected a date as 'YYYY-MM-DD'")
+	}
+	return dateKey(date.Date()), nil
+}
+
+func dateKey(year int, month time.Month, day int) int {
+	return year*10000 + int(month)*100 + day
+}
+
+// matches returns true if the window applies to the local date and the local clock time (in seconds since midnight).
+func (w tariffWindow) matches(year int, month time.Month, day int, clock int) bool {
+	if w.from < w.to {
+		if clock < w.from || clock >= w.to {
+			return false
+		}
+	} else if clock < w.from && clock >= w.to {
+		return false
+	}
+	if w.months != nil && !w.months[month] {
+		return false
+	}
+	if w.weekdays != nil && !w.weekdays[time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday()] {
+		return false
+	}
+	key := dateKey(year, month, day)
+	return (w.validFrom == 0 || key >= w.validFrom) && (w.validUntil == 0 || key <= w.validUntil)
+}
+
+// TariffCalendar applies a TariffModel to the local time of a LocalDaysCalculator. Create it with TariffModel.Build.
+type TariffCalendar struct {
+	calculator    local_days.LocalDaysCalculator
+	location      *time.Location
+	defaultTariff string
+	windows       []tariffWindow
+}
+
+// TariffSegment is a part of an interval with a single tariff.
+type TariffSegment struct {
+	local_days.Interval
+	// Tariff is the tariff of the whole segment, e.g. "HT"
+	Tariff string
+}
+
+// Tariff returns the tariff at timestamp, i.e. the tariff of the first window that matches the local date and clock time of timestamp or the default tariff.
+// During the hour that is repeated at the end of DST, the local clock time is the same for both occurrences.
+func (c TariffCalendar) Tariff(timestamp time.Time) string {
+	local := timestamp.In(c.location)
+	year, month, day := local.Date()
+	clock := local.Hour()*60*60 + local.Minute()*60 + local.Second()
+	for _, window := range c.windows {
+		if window.matches(year, month, day, clock) {
+			return window.tariff
+		}
+	}
+	return c.defaultTariff
+}
+
+// Split splits the interval [from, to) into consecutive segments of the same tariff. Adjacent segments always have different tariffs.
+// The segment boundaries are the UTC timestamps at which the local clock reaches the start or end of a window, so they also respect the days on which DST starts or ends. The result is empty if to is not after from.
+func (c TariffCalendar) Split(from time.Time, to time.Time) []TariffSegment {
+	from, to = from.UTC(), to.UTC()
+	if !to.After(from) {
+		return nil
+	}
+	boundaries := []time.Time{from}
+	for startOfDay := c.calculator.StartOfLocalDay(from); startOfDay.Before(to); {
+		startOfNextDay := c.calculator.StartOfNextLocalDay(startOfDay)
+		for _, boundary := range c.boundariesOfLocalDay(startOfDay, startOfNextDay) {
+			if boundary.After(from) && boundary.Before(to) {
+				boundaries = append(boundaries, boundary)
+			}
+		}
+		startOfDay = startOfNextDay
+	}
+	sort.Slice(boundaries, func(i, j int) bool { return boundaries[i].Before(boundaries[j]) })
+	boundaries = append(boundaries, to)
+	var segments []TariffSegment
+	for i := 0; i < len(boundaries)-1; i++ {
+		if !boundaries[i+1].After(boundaries[i]) {
+			continue
+		}
+		tariff := c.Tariff(boundaries[i])
+		if len(segments) > 0 && segments[len(segments)-1].Tariff == tariff {
+			segments[len(segments)-1].End = boundaries[i+1]
+			continue
+		}
+		segments = append(segments, TariffSegment{Interval: local_days.Interval{Start: boundaries[i], End: boundaries[i+1]}, Tariff: tariff})
+	}
+	return segments
+}
+
+// boundariesOfLocalDay returns all timestamps within [startOfDay, startOfNextDay) at which the tariff may change: the UTC offset transitions and the occurrences of local midnight and of all window clock times.
+func (c TariffCalendar) boundariesOfLocalDay(startOfDay time.Time, startOfNextDay time.Time) []time.Time {
+	boundaries := local_days.OffsetTransitions(c.location, startOfDay, startOfNextDay.Add(-time.Second))
+	clocks := []int{0}
+	for _, window := range c.windows {
+		clocks = append(clocks, window.from, window.to)
+	}
+	// the local day may start at another clock time than midnight, so it may cover the clock times of two local dates
+	date, _ := local_days.LocalDate(c.calculator, startOfDay)
+	for _, localDate := range []time.Time{date, date.AddDate(0, 0, 1)} {
+		for _, clock := range clocks {
+			// the local clock time might occur twice (end of DST) or not at all (start of DST, then it's the transition)
+			for _, boundary := range local_days.LocalClockTimes(c.location, localDate, time.Duration(clock)*time.Second) {
+				if !boundary.Before(startOfDay) && boundary.Before(startOfNextDay) {
+					boundaries = append(boundaries, boundary)
+				}
+			}
+		}
+	}
+	return boundaries
+}
@@ -0,0 +1,278 @@
+package local_days
+
+import (
+	"fmt"
+	"time"
+)
+
+// The bulk functions process whole slices of timestamps (as time.Time or as unix seconds) for time-series processing. They write their results into dst, reusing its capacity (pass nil to allocate), and return the filled slice which has the same length as the input.
+// For the calculators of this package they avoid the dispatch through the LocalDaysCalculator interface per element; for calculators from NewPrecomputedLocalDaysCalculator they additionally profit from (mostly) sorted inputs.
+
+// BulkStartOfLocalDay returns the StartOfLocalDay of each timestamp.
+func BulkStartOfLocalDay(calculator LocalDaysCalculator, timestamps []time.Time, dst []time.Time) []time.Time {
+	b := newBulkCalculator(calculator)
+	dst = resize(dst, len(timestamps))
+	for i, timestamp := range timestamps {
+		dst[i] = b.startOfLocalDay(timestamp)
+	}
+	return dst
+}
+
+// BulkStartOfLocalDayUnix returns the StartOfLocalDay of each timestamp given as unix seconds as unix seconds (truncated, if a local day does not start at a full second).
+func BulkStartOfLocalDayUnix(calculator LocalDaysCalculator, timestamps []int64, dst []int64) []int64 {
+	b := newBulkCalculator(calculator)
+	dst = resizeInt64(dst, len(timestamps))
+	for i, timestamp := range timestamps {
+		dst[i] = b.startOfLocalDayUnix(timestamp)
+	}
+	return dst
+}
+
+// BulkLocalDayIndex returns the local day index of each timestamp, i.e. the number of days between 1970-01-01 and the local date of the local day of timestamp (see DateOfLocalDayIndex).
+// Consecutive local days usually have consecutive indexes, unless a local date is skipped. It returns an error if calculator is neither created by this package nor provides a Location.
+func BulkLocalDayIndex(calculator LocalDaysCalculator, timestamps []time.Time, dst []int32) ([]int32, error) {
+	b := newBulkCalculator(calculator)
+	if err := b.supportsLocalDayIndex("BulkLocalDayIndex"); err != nil {
+		return nil, err
+	}
+	dst = resizeInt32(dst, len(timestamps))
+	for i, timestamp := range timestamps {
+		dst[i] = b.localDayIndex(timestamp)
+	}
+	return dst, nil
+}
+
+// BulkLocalDayIndexUnix is like BulkLocalDayIndex for timestamps given as unix seconds.
+func BulkLocalDayIndexUnix(calculator LocalDaysCalculator, timestamps []int64, dst []int32) ([]int32, error) {
+	b := newBulkCalculator(calculator)
+	if err := b.supportsLocalDayIndex("BulkLocalDayIndexUnix"); err != nil {
+		return nil, err
+	}
+	dst = resizeInt32(dst, len(timestamps))
+	for i, timestamp := range timestamps {
+		dst[i] = b.localDayIndexUnix(timestamp)
+	}
+	return dst, nil
+}
+
+// BulkLocalMonthKey returns the local month key (year*100+month, e.g. 202211 for November 2022) of the local day of each timestamp. It returns an error for the same calculators as BulkLocalDayIndex.
+func BulkLocalMonthKey(calculator LocalDaysCalculator, timestamps []time.Time, dst []int32) ([]int32, error) {
+	b := newBulkCalculator(calculator)
+	if err := b.supportsLocalDayIndex("BulkLocalMonthKey"); err != nil {
+		return nil, err
+	}
+	dst = resizeInt32(dst, len(timestamps))
+	for i, timestamp := range timestamps {
+		dst[i] = b.localMonthKey(b.localDayIndex(timestamp))
+	}
+	return dst, nil
+}
+
+// BulkLocalMonthKeyUnix is like BulkLocalMonthKey for timestamps given as unix seconds.
+func BulkLocalMonthKeyUnix(calculator LocalDaysCalculator, timestamps []int64, dst []int32) ([]int32, error) {
+	b := newBulkCalculator(calculator)
+	if err := b.supportsLocalDayIndex("BulkLocalMonthKeyUnix"); err != nil {
+		return nil, err
+	}
+	dst = resizeInt32(dst, len(timestamps))
+	for i, timestamp := range timestamps {
+		dst[i] = b.localMonthKey(b.localDayIndexUnix(timestamp))
+	}
+	return dst, nil
+}
+
+// LocalDate returns the local date of the local day of timestamp as midnight UTC, e.g. 2022-11-16 00:00 UTC for 2022-11-16 12:00 UTC in "Europe/Berlin". It returns an error for the same calculators as BulkLocalDayIndex.
+func LocalDate(calculator LocalDaysCalculator, timestamp time.Time) (time.Time, error) {
+	b := newBulkCalculator(calculator)
+	if err := b.supportsLocalDayIndex("LocalDate"); err != nil {
+		return time.Time{}, err
+	}
+	return DateOfLocalDayIndex(b.localDayIndex(timestamp)), nil
+}
+
+// DateOfLocalDayIndex returns the local date of a local day index (see BulkLocalDayIndex) as midnight UTC.
+func DateOfLocalDayIndex(index int32) time.Time {
+	return time.Unix(int64(index)*SecondsPerDay, 0).UTC()
+}
+
+// localMonthKey returns the local month key of a local day index, which is usually the same as the one of the previous timestamp.
+func (b *bulkCalculator) localMonthKey(dayIndex int32) int32 {
+	if dayIndex != b.previousDayIndex || b.previousMonthKey == 0 {
+		b.previousDayIndex = dayIndex
+		b.previousMonthKey = localMonthKey(dayIndex)
+	}
+	return b.previousMonthKey
+}
+
+// localMonthKey returns the local month key of a local day index using the allocation free civil_from_days algorithm by Howard Hinnant: https://howardhinnant.github.io/date_algorithms.html#civil_from_days
+func localMonthKey(dayIndex int32) int32 {
+	days := int64(dayIndex) + 719468
+	era := floorDiv(days, 146097)
+	dayOfEra := days - era*146097
+	yearOfEra := (dayOfEra - dayOfEra/1460 + dayOfEra/36524 - dayOfEra/146096) / 365
+	dayOfYear := dayOfEra - (365*yearOfEra + yearOfEra/4 - yearOfEra/100)
+	shiftedMonth := (5*dayOfYear + 2) / 153 // 0 is March
+	month := shiftedMonth + 3
+	if month > 12 {
+		month -= 12
+	}
+	year := yearOfEra + era*400
+	if month <= 2 {
+		year++
+	}
+	return int32(year*100 + month)
+}
+
+func resize(dst []time.Time, length int) []time.Time {
+	if cap(dst) < length {
+		return make([]time.Time, length)
+	}
+	return dst[:length]
+}
+
+func resizeInt64(dst []int64, length int) []int64 {
+	if cap(dst) < length {
+		return make([]int64, length)
+	}
+	return dst[:length]
+}
+
+func resizeInt32(dst []int32, length int) []int32 {
+	if cap(dst) < length {
+		return make([]int32, length)
+	}
+	return dst[:length]
+}
+
+// bulkCalculator picks the fastest way to process many timestamps with the given calculator.
+type bulkCalculator struct {
+	calculator     LocalDaysCalculator
+	precomputed    *precomputedLocalDaysCalculator
+	converterBased *converterBasedLocalDaysCalculator
+	// cursor is the index of the previous local day in the tables of precomputed
+	cursor int
+	// previousDayIndex and previousMonthKey cache the previous result of localMonthKey
+	previousDayIndex int32
+	previousMonthKey int32
+}
+
+func newBulkCalculator(calculator LocalDaysCalculator) *bulkCalculator {
+	b := &bulkCalculator{calculator: calculator}
+	switch c := calculator.(type) {
+	case *precomputedLocalDaysCalculator:
+		b.precomputed = c
+	case converterBasedLocalDaysCalculator:
+		b.converterBased = &c
+	}
+	return b
+}
+
+// precomputedIndex returns the index of the local day of timestamp in the tables of the precomputed calculator or false if it's outside of the tables.
+func (b *bulkCalculator) precomputedIndex(timestamp time.Time) (int, bool) {
+	p := b.precomputed
+	if timestamp.Before(p.start) || !timestamp.Before(p.end) {
+		return 0, false
+	}
+	if p.strictInputValidation {
+		p.validateInput(timestamp)
+	}
+	return b.precomputedIndexOfNanos(timestamp.UnixNano()), true
+}
+
+// precomputedIndexUnix is like precomputedIndex for a timestamp given as unix seconds.
+func (b *bulkCalculator) precomputedIndexUnix(seconds int64) (int, bool) {
+	p := b.precomputed
+	// the first comparison makes sure that the nanoseconds do not overflow
+	if seconds < p.start.Unix()-1 || seconds > p.end.Unix() {
+		return 0, false
+	}
+	nanos := seconds * int64(time.Second)
+	if nanos < p.starts[0] || nanos >= p.starts[len(p.starts)-1] {
+		return 0, false
+	}
+	return b.precomputedIndexOfNanos(nanos), true
+}
+
+// precomputedIndexOfNanos returns the index of the local day of a timestamp within the tables, starting the search at the local day of the previous timestamp, because time series are usually sorted.
+func (b *bulkCalculator) precomputedIndexOfNanos(nanos int64) int {
+	p := b.precomputed
+	if p.starts[b.cursor] <= nanos && nanos < p.starts[b.cursor+1] {
+		return b.cursor
+	}
+	if b.cursor+2 < len(p.starts) && p.starts[b.cursor+1] <= nanos && nanos < p.starts[b.cursor+2] {
+		b.cursor++
+		return b.cursor
+	}
+	b.cursor = p.indexOfNanos(nanos)
+	return b.cursor
+}
+
+// startOfLocalDayUnix is like startOfLocalDay for timestamps given as unix seconds.
+func (b *bulkCalculator) startOfLocalDayUnix(seconds int64) int64 {
+	if b.precomputed != nil {
+		if index, ok := b.precomputedIndexUnix(seconds); ok {
+			return floorDiv(b.precomputed.starts[index], int64(time.Second))
+		}
+	}
+	return b.startOfLocalDay(time.Unix(seconds, 0).UTC()).Unix()
+}
+
+// localDayIndexUnix is like localDayIndex for timestamps given as unix seconds.
+func (b *bulkCalculator) localDayIndexUnix(seconds int64) int32 {
+	if b.precomputed != nil {
+		if index, ok := b.precomputedIndexUnix(seconds); ok {
+			return b.precomputed.days[index]
+		}
+	}
+	return b.localDayIndex(time.Unix(seconds, 0).UTC())
+}
+
+// floorDiv divides rounding towards negative infinity like time.Time.Unix.
+func floorDiv(dividend int64, divisor int64) int64 {
+	quotient := dividend / divisor
+	if dividend%divisor < 0 {
+		quotient--
+	}
+	return quotient
+}
+
+func (b *bulkCalculator) startOfLocalDay(timestamp time.Time) time.Time {
+	switch {
+	case b.precomputed != nil:
+		if index, ok := b.precomputedIndex(timestamp); ok {
+			return b.precomputed.startAt(index)
+		}
+		return b.precomputed.converterBasedLocalDaysCalculator.StartOfLocalDay(timestamp)
+	case b.converterBased != nil:
+		return b.converterBased.StartOfLocalDay(timestamp)
+	default:
+		return b.calculator.StartOfLocalDay(timestamp)
+	}
+}
+
+func (b *bulkCalculator) supportsLocalDayIndex(function string) error {
+	if b.precomputed == nil && b.converterBased == nil && Location(b.calculator) == nil {
+		return &InvalidArgumentError{Function: function, Argument: "calculator", Value: fmt.Sprintf("%T", b.calculator), Err: fmt.Errorf("the local dates of a calculator without location are unknown")}
+	}
+	return nil
+}
+
+func (b *bulkCalculator) localDayIndex(timestamp time.Time) int32 {
+	var day time.Time
+	switch {
+	case b.precomputed != nil:
+		if index, ok := b.precomputedIndex(timestamp); ok {
+			return b.precomputed.days[index]
+		}
+		b.precomputed.validateInput(timestamp)
+		day = b.precomputed.localDay(timestamp)
+	case b.converterBased != nil:
+		b.converterBased.validateInput(timestamp)
+		day = b.converterBased.localDay(timestamp)
+	default:
+		// the local day starts on its local date (even if the day start is configured or local midnight is skipped)
+		year, month, dayOfMonth := b.calculator.StartOfLocalDay(timestamp).In(Location(b.calculator)).Date()
+		day = time.Date(year, month, dayOfMonth, 0, 0, 0, 0, time.UTC)
+	}
+	return int32(day.Unix() / SecondsPerDay)
+}
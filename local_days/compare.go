@@ -0,0 +1,78 @@
+package local_days
+
+import (
+	"time"
+)
+
+// StartDifference describes a period (e.g. a local day) that starts at different points in time according to two LocalDaysCalculators.
+type StartDifference struct {
+	// Reference is the start of the period according to the reference calculator (in UTC)
+	Reference time.Time
+	// Other is the start of the same period according to the other calculator (in UTC)
+	Other time.Time
+}
+
+// Shift returns how much later the period starts according to the other calculator than according to the reference calculator. It's negative if it starts earlier.
+func (d StartDifference) Shift() time.Duration {
+	return d.Other.Sub(d.Reference)
+}
+
+// CompareLocalDayStarts returns the local days of the reference calculator that start within [from, to) and start at a different point in time according to the other calculator (e.g. to reconcile a calendar in permanent standard time with the official one).
+// A day of the reference calculator is matched with the local day of the other calculator that contains its middle, which is the same local date as long as the local times of both calculators differ by less than 12 hours.
+func CompareLocalDayStarts(reference LocalDaysCalculator, other LocalDaysCalculator, from time.Time, to time.Time) []StartDifference {
+	var differences []StartDifference
+	startOfDay := reference.StartOfLocalDay(from)
+	if startOfDay.Before(from) {
+		startOfDay = reference.StartOfNextLocalDay(from)
+	}
+	for startOfDay.Before(to) {
+		startOfNextDay := reference.StartOfNextLocalDay(startOfDay)
+		middleOfDay := startOfDay.Add(startOfNextDay.Sub(startOfDay) / 2)
+		if otherStartOfDay := other.StartOfLocalDay(middleOfDay); !otherStartOfDay.Equal(startOfDay) {
+			differences = append(differences, StartDifference{Reference: startOfDay, Other: otherStartOfDay})
+		}
+		startOfDay = startOfNextDay
+	}
+	return differences
+}
+
+// CompareLocalMonthStarts returns the local months of the reference calculator that start within [from, to) and start at a different point in time according to the other calculator.
+// Months are matched like the days in CompareLocalDayStarts.
+func CompareLocalMonthStarts(reference LocalDaysCalculator, other LocalDaysCalculator, from time.Time, to time.Time) []StartDifference {
+	var differences []StartDifference
+	startOfMonth := reference.StartOfLocalMonth(from)
+	if startOfMonth.Before(from) {
+		startOfMonth = reference.StartOfNextLocalMonth(from)
+	}
+	for startOfMonth.Before(to) {
+		startOfNextMonth := reference.StartOfNextLocalMonth(startOfMonth)
+		middleOfMonth := startOfMonth.Add(startOfNextMonth.Sub(startOfMonth) / 2)
+		if otherStartOfMonth := other.StartOfLocalMonth(middleOfMonth); !otherStartOfMonth.Equal(startOfMonth) {
+			differences = append(differences, StartDifference{Reference: startOfMonth, Other: otherStartOfMonth})
+		}
+		startOfMonth = startOfNextMonth
+	}
+	return differences
+}
+
+// CalculatorDifferences are the local days and months whose starts differ between two LocalDaysCalculators (see CompareCalculators).
+type CalculatorDifferences struct {
+	// LocalDays are the local days that start at different points in time
+	LocalDays []StartDifference
+	// LocalMonths are the local months that start at different points in time
+	LocalMonths []StartDifference
+}
+
+// IsEmpty returns true if and only if there are no differences at all.
+func (d CalculatorDifferences) IsEmpty() bool {
+	return len(d.LocalDays) == 0 && len(d.LocalMonths) == 0
+}
+
+// CompareCalculators returns all local days and months that start within [from, to) according to the reference calculator and start at a different point in time according to the other calculator.
+// Use it to find out which local day boundaries in your historic or future data shift when the tzdata changes, e.g. by comparing calculators created by NewTZDataBasedLocalTimeConverter from two releases of the tzdata.
+func CompareCalculators(reference LocalDaysCalculator, other LocalDaysCalculator, from time.Time, to time.Time) CalculatorDifferences {
+	return CalculatorDifferences{
+		LocalDays:   CompareLocalDayStarts(reference, other, from, to),
+		LocalMonths: CompareLocalMonthStarts(reference, other, from, to),
+	}
+}
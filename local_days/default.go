@@ -0,0 +1,140 @@
+package local_days
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultZoneEnvironmentVariable is the environment variable that holds the name of the time zone of the default calculator (e.g. "Europe/Berlin") if none has been set using SetDefault.
+const DefaultZoneEnvironmentVariable = "LOCAL_DAYS_ZONE"
+
+// ErrNoDefault is returned by Default (and all package level functions that use it) if neither SetDefault has been called nor the environment variable LOCAL_DAYS_ZONE is set.
+var ErrNoDefault = errors.New("No default LocalDaysCalculator is configured. Call local_days.SetDefault or set the environment variable " + DefaultZoneEnvironmentVariable + " (e.g. to \"Europe/Berlin\")")
+
+var (
+	defaultMutex      sync.RWMutex
+	defaultCalculator LocalDaysCalculator
+)
+
+// SetDefault sets the calculator that is used by the package level functions like StartOfLocalDay. Setting nil removes the default, so that the environment variable LOCAL_DAYS_ZONE is used again.
+func SetDefault(calculator LocalDaysCalculator) {
+	defaultMutex.Lock()
+	defer defaultMutex.Unlock()
+	defaultCalculator = calculator
+}
+
+// Default returns the calculator that is used by the package level functions. This is the calculator set using SetDefault or, if there is none, the (cached, see Get) calculator for the zone in the environment variable LOCAL_DAYS_ZONE.
+// It returns ErrNoDefault if neither is configured and an error that names the environment variable if its zone cannot be loaded.
+func Default() (LocalDaysCalculator, error) {
+	defaultMutex.RLock()
+	calculator := defaultCalculator
+	defaultMutex.RUnlock()
+	if calculator != nil {
+		return calculator, nil
+	}
+	zoneName := os.Getenv(DefaultZoneEnvironmentVariable)
+	if zoneName == "" {
+		return nil, ErrNoDefault
+	}
+	calculator, err := Get(zoneName)
+	if err != nil {
+		return nil, fmt.Errorf("The environment variable %s=%s is invalid: %w", DefaultZoneEnvironmentVariable, zoneName, err)
+	}
+	return calculator, nil
+}
+
+// AddLocalDays calls AddLocalDays of the Default calculator.
+func AddLocalDays(timestamp time.Time, number int) (time.Time, error) {
+	calculator, err := Default()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return calculator.AddLocalDays(timestamp, number), nil
+}
+
+// StartOfLocalDay calls StartOfLocalDay of the Default calculator.
+func StartOfLocalDay(timestamp time.Time) (time.Time, error) {
+	return callDefault(timestamp, LocalDaysCalculator.StartOfLocalDay)
+}
+
+// StartOfNextLocalDay calls StartOfNextLocalDay of the Default calculator.
+func StartOfNextLocalDay(timestamp time.Time) (time.Time, error) {
+	return callDefault(timestamp, LocalDaysCalculator.StartOfNextLocalDay)
+}
+
+// StartOfLocalWeek calls StartOfLocalWeek of the Default calculator (see LocalWeeksCalculator).
+func StartOfLocalWeek(timestamp time.Time) (time.Time, error) {
+	return callDefault(timestamp, startOfLocalWeek)
+}
+
+// StartOfNextLocalWeek calls StartOfNextLocalWeek of the Default calculator (see LocalWeeksCalculator).
+func StartOfNextLocalWeek(timestamp time.Time) (time.Time, error) {
+	return callDefault(timestamp, startOfNextLocalWeek)
+}
+
+// StartOfLocalMonth calls StartOfLocalMonth of the Default calculator.
+func StartOfLocalMonth(timestamp time.Time) (time.Time, error) {
+	return callDefault(timestamp, LocalDaysCalculator.StartOfLocalMonth)
+}
+
+// StartOfNextLocalMonth calls StartOfNextLocalMonth of the Default calculator.
+func StartOfNextLocalMonth(timestamp time.Time) (time.Time, error) {
+	return callDefault(timestamp, LocalDaysCalculator.StartOfNextLocalMonth)
+}
+
+// GetLocalWeekday calls GetLocalWeekday of the Default calculator.
+func GetLocalWeekday(timestamp time.Time) (time.Weekday, error) {
+	calculator, err := Default()
+	if err != nil {
+		return 0, err
+	}
+	return calculator.GetLocalWeekday(timestamp), nil
+}
+
+// NextLocalWeekday calls NextLocalWeekday of the Default calculator. It returns an *InvalidArgumentError if weekday is not a valid time.Weekday.
+func NextLocalWeekday(timestamp time.Time, weekday time.Weekday) (time.Time, error) {
+	if err := validateWeekday("NextLocalWeekday", weekday); err != nil {
+		return time.Time{}, err
+	}
+	calculator, err := Default()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return calculator.NextLocalWeekday(timestamp, weekday), nil
+}
+
+// IsLocalMidnight calls IsLocalMidnight of the Default calculator.
+func IsLocalMidnight(timestamp time.Time) (bool, error) {
+	return callDefaultPredicate(timestamp, LocalDaysCalculator.IsLocalMidnight)
+}
+
+// IsLocalHoliday calls IsLocalHoliday of the Default calculator (see BusinessDaysCalculator).
+func IsLocalHoliday(timestamp time.Time) (bool, error) {
+	return callDefaultPredicate(timestamp, isLocalHoliday)
+}
+
+// IsLocalBusinessDay calls IsLocalBusinessDay of the Default calculator (see BusinessDaysCalculator).
+func IsLocalBusinessDay(timestamp time.Time) (bool, error) {
+	return callDefaultPredicate(timestamp, isLocalBusinessDay)
+}
+
+// callDefault calls method of the Default calculator with timestamp.
+func callDefault(timestamp time.Time, method func(LocalDaysCalculator, time.Time) time.Time) (time.Time, error) {
+	calculator, err := Default()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return method(calculator, timestamp), nil
+}
+
+// callDefaultPredicate calls method of the Default calculator with timestamp.
+func callDefaultPredicate(timestamp time.Time, method func(LocalDaysCalculator, time.Time) bool) (bool, error) {
+	calculator, err := Default()
+	if err != nil {
+		return false, err
+	}
+	return method(calculator, timestamp), nil
+}
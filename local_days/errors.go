@@ -0,0 +1,119 @@
+package local_days
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// The sentinel errors of this package. Use errors.Is to check for them and errors.As to get the structured error (if any) that carries the details.
+var (
+	// ErrInvalidArgument is matched by every *InvalidArgumentError.
+	ErrInvalidArgument = errors.New("The argument is invalid")
+	// ErrInvalidWeekday is the cause of an *InvalidArgumentError for a time.Weekday that is not between time.Sunday and time.Saturday.
+	ErrInvalidWeekday = errors.New("The weekday is not between Sunday and Saturday")
+	// ErrNilArgument is the cause of an *InvalidArgumentError for a nil argument.
+	ErrNilArgument = errors.New("The argument must not be nil")
+	// ErrZoneNotFound is matched by every *ZoneNotFoundError.
+	ErrZoneNotFound = errors.New("The timezone data could not be found")
+	// ErrNotUTC is the cause of a *TimestampError for a timestamp that is not in UTC.
+	ErrNotUTC = errors.New("The timestamp is not in UTC")
+	// ErrMonotonicClockReading is the cause of a *TimestampError for a timestamp that carries a monotonic clock reading.
+	ErrMonotonicClockReading = errors.New("The timestamp carries a monotonic clock reading. Use timestamp.UTC()")
+	// ErrInvalidSpec is matched by every *SpecFieldError.
+	ErrInvalidSpec = errors.New("The calculator spec is invalid")
+	// ErrCalculatorPanicked is matched by every *PanicError.
+	ErrCalculatorPanicked = errors.New("The calculator panicked")
+)
+
+// InvalidArgumentError is returned if an argument of a function or method is invalid, e.g. for NextLocalWeekday with time.Weekday(7).
+type InvalidArgumentError struct {
+	// Function is the name of the function or method, e.g. "NextLocalWeekday"
+	Function string
+	// Argument is the name of the invalid argument, e.g. "weekday"
+	Argument string
+	// Value is the invalid value
+	Value interface{}
+	// Err is the cause, e.g. ErrInvalidWeekday
+	Err error
+}
+
+func (e *InvalidArgumentError) Error() string {
+	return fmt.Sprintf("The argument %s=%v of %s is invalid: %v", e.Argument, e.Value, e.Function, e.Err)
+}
+
+func (e *InvalidArgumentError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes errors.Is(err, ErrInvalidArgument) true for every *InvalidArgumentError.
+func (e *InvalidArgumentError) Is(target error) bool {
+	return target == ErrInvalidArgument
+}
+
+// ZoneNotFoundError is returned if the timezone data for a zone name cannot be loaded.
+type ZoneNotFoundError struct {
+	// ZoneName is the name of the zone, e.g. "Europe/Berlin"
+	ZoneName string
+	// Err is the error returned by time.LoadLocation
+	Err error
+}
+
+func (e *ZoneNotFoundError) Error() string {
+	return fmt.Sprintf("The timezone data for '%s' could not be found. Import \"time/tzdata\" anywhere in your project or build with `-tags timetzdata`: https://pkg.go.dev/time/tzdata", e.ZoneName)
+}
+
+func (e *ZoneNotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes errors.Is(err, ErrZoneNotFound) true for every *ZoneNotFoundError.
+func (e *ZoneNotFoundError) Is(target error) bool {
+	return target == ErrZoneNotFound
+}
+
+// TimestampError is returned if a timestamp does not follow the conventions of this package (see ValidateTimestamp).
+type TimestampError struct {
+	// Timestamp is the invalid timestamp
+	Timestamp time.Time
+	// Err is the cause, i.e. ErrNotUTC or ErrMonotonicClockReading
+	Err error
+}
+
+func (e *TimestampError) Error() string {
+	return fmt.Sprintf("The timestamp %s is invalid: %v", e.Timestamp, e.Err)
+}
+
+func (e *TimestampError) Unwrap() error {
+	return e.Err
+}
+
+// PanicError is returned by a LocalDaysCalculatorV2 if the wrapped LocalDaysCalculator panicked.
+type PanicError struct {
+	// Method is the name of the method that panicked, e.g. "StartOfLocalDay"
+	Method string
+	// Value is the value recovered from the panic
+	Value interface{}
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("The calculator panicked in %s: %v", e.Method, e.Value)
+}
+
+// Is makes errors.Is(err, ErrCalculatorPanicked) true for every *PanicError.
+func (e *PanicError) Is(target error) bool {
+	return target == ErrCalculatorPanicked
+}
+
+// Is makes errors.Is(err, ErrInvalidSpec) true for every *SpecFieldError.
+func (e *SpecFieldError) Is(target error) bool {
+	return target == ErrInvalidSpec
+}
+
+// validateWeekday returns an *InvalidArgumentError if weekday is not a valid time.Weekday.
+func validateWeekday(function string, weekday time.Weekday) error {
+	if weekday < time.Sunday || weekday > time.Saturday {
+		return &InvalidArgumentError{Function: function, Argument: "weekday", Value: int(weekday), Err: ErrInvalidWeekday}
+	}
+	return nil
+}
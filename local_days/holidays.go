@@ -0,0 +1,49 @@
+package local_days
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// HolidayCalendar decides which local dates are holidays (see WithHolidayCalendar).
+type HolidayCalendar interface {
+	// IsHoliday returns true if and only if the local date given by year, month and day is a holiday.
+	IsHoliday(year int, month time.Month, day int) bool
+}
+
+// HolidayCalendarFunc is an adapter to use an ordinary function as HolidayCalendar.
+type HolidayCalendarFunc func(year int, month time.Month, day int) bool
+
+// IsHoliday calls f(year, month, day).
+func (f HolidayCalendarFunc) IsHoliday(year int, month time.Month, day int) bool {
+	return f(year, month, day)
+}
+
+var (
+	holidayRegionsMutex sync.RWMutex
+	holidayRegions      = map[string]HolidayCalendar{}
+)
+
+// RegisterHolidayRegion makes calendar available under the name region (e.g. "DE") for WithHolidayRegion and CalculatorSpec.HolidayRegion. Registering a region again replaces its calendar.
+// It returns an error if region is empty or calendar is nil.
+func RegisterHolidayRegion(region string, calendar HolidayCalendar) error {
+	if region == "" {
+		return fmt.Errorf("The holiday region must not be empty")
+	}
+	if calendar == nil {
+		return fmt.Errorf("The holiday calendar of region '%s' must not be nil", region)
+	}
+	holidayRegionsMutex.Lock()
+	defer holidayRegionsMutex.Unlock()
+	holidayRegions[region] = calendar
+	return nil
+}
+
+// lookupHolidayRegion returns the calendar registered for region (see RegisterHolidayRegion).
+func lookupHolidayRegion(region string) (HolidayCalendar, bool) {
+	holidayRegionsMutex.RLock()
+	defer holidayRegionsMutex.RUnlock()
+	calendar, ok := holidayRegions[region]
+	return calendar, ok
+}
@@ -0,0 +1,213 @@
+package local_days
+
+import (
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+// Call describes a single call of a LocalDaysCalculator method that has been observed by an instrumented calculator (see NewInstrumentedLocalDaysCalculator).
+type Call struct {
+	// Method is the name of the called method, e.g. "StartOfLocalDay"
+	Method string
+	// ZoneName is the zone name of the calculator (see ZoneName)
+	ZoneName string
+	// Timestamp is the timestamp the method has been called with
+	Timestamp time.Time
+	// Argument is the additional argument of the method (the number of days for AddLocalDays, the weekday for NextLocalWeekday) or nil
+	Argument interface{}
+	// Result is the return value of the method (a time.Time, time.Weekday or bool)
+	Result interface{}
+	// Duration is how long the call took
+	Duration time.Duration
+}
+
+// CallHook receives the calls observed by an instrumented calculator. It's called synchronously after each (sampled) call, so it should return quickly and has to be safe for concurrent use if the calculator is.
+type CallHook interface {
+	OnCall(call Call)
+}
+
+// CallHookFunc is an adapter to use an ordinary function as CallHook.
+type CallHookFunc func(call Call)
+
+// OnCall calls f(call).
+func (f CallHookFunc) OnCall(call Call) {
+	f(call)
+}
+
+// NewLogCallHook returns a CallHook that prints every call using logger (log.Default() if nil).
+func NewLogCallHook(logger *log.Logger) CallHook {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return CallHookFunc(func(call Call) {
+		logger.Print(call.String())
+	})
+}
+
+func (c Call) String() string {
+	if c.Argument != nil {
+		return fmt.Sprintf("%s(%s, %v) = %v in %s (%s)", c.Method, c.Timestamp.Format(time.RFC3339Nano), c.Argument, formatCallResult(c.Result), c.ZoneName, c.Duration)
+	}
+	return fmt.Sprintf("%s(%s) = %v in %s (%s)", c.Method, c.Timestamp.Format(time.RFC3339Nano), formatCallResult(c.Result), c.ZoneName, c.Duration)
+}
+
+func formatCallResult(result interface{}) interface{} {
+	if timestamp, ok := result.(time.Time); ok {
+		return timestamp.Format(time.RFC3339Nano)
+	}
+	return result
+}
+
+// InstrumentationOption configures an instrumented calculator (see NewInstrumentedLocalDaysCalculator).
+type InstrumentationOption func(*instrumentedLocalDaysCalculator)
+
+// WithSampling reports only every n-th call (starting with the first one) to keep the overhead low in production. Calls that are not sampled are neither timed nor reported. It panics if n is less than 1.
+func WithSampling(n int) InstrumentationOption {
+	if n < 1 {
+		log.Panic(fmt.Errorf("The sampling interval %d is less than 1", n))
+	}
+	return func(i *instrumentedLocalDaysCalculator) {
+		i.sampleEvery = uint64(n)
+	}
+}
+
+// NewInstrumentedLocalDaysCalculator returns a LocalDaysCalculator that delegates all calls to calculator and reports the calls of its local day methods (with inputs, outputs, zone and duration) to hook.
+// If hook is nil, the calls are logged using log/slog at debug level (or ignored for Go versions before 1.21, see NewLogCallHook). Location and Now are not reported. It panics if calculator is nil.
+// Like LocalDaysCalculatorV2, the returned calculator implements LocalWeeksCalculator, BusinessDaysCalculator and Clock even if calculator does not: then local weeks start on Monday, there are no holidays and the system clock is used.
+func NewInstrumentedLocalDaysCalculator(calculator LocalDaysCalculator, hook CallHook, options ...InstrumentationOption) LocalDaysCalculator {
+	if calculator == nil {
+		log.Panic(fmt.Errorf("The calculator must not be nil"))
+	}
+	if hook == nil {
+		hook = defaultCallHook()
+	}
+	instrumented := instrumentedLocalDaysCalculator{
+		calculator:  calculator,
+		hook:        hook,
+		zoneName:    ZoneName(calculator),
+		sampleEvery: 1,
+		calls:       new(uint64),
+	}
+	for _, option := range options {
+		option(&instrumented)
+	}
+	return instrumented
+}
+
+type instrumentedLocalDaysCalculator struct {
+	calculator LocalDaysCalculator
+	hook       CallHook
+	zoneName   string
+	// sampleEvery is the n of "every n-th call is reported"
+	sampleEvery uint64
+	// calls counts all calls for the sampling
+	calls *uint64
+}
+
+// sampled returns true if the current call should be reported.
+func (i instrumentedLocalDaysCalculator) sampled() bool {
+	if i.sampleEvery == 1 {
+		return true
+	}
+	return (atomic.AddUint64(i.calls, 1)-1)%i.sampleEvery == 0
+}
+
+func (i instrumentedLocalDaysCalculator) timeCall(method string, timestamp time.Time, argument interface{}, call func() time.Time) time.Time {
+	if !i.sampled() {
+		return call()
+	}
+	start := time.Now()
+	result := call()
+	i.hook.OnCall(Call{Method: method, ZoneName: i.zoneName, Timestamp: timestamp, Argument: argument, Result: result, Duration: time.Since(start)})
+	return result
+}
+
+func (i instrumentedLocalDaysCalculator) boolCall(method string, timestamp time.Time, call func() bool) bool {
+	if !i.sampled() {
+		return call()
+	}
+	start := time.Now()
+	result := call()
+	i.hook.OnCall(Call{Method: method, ZoneName: i.zoneName, Timestamp: timestamp, Result: result, Duration: time.Since(start)})
+	return result
+}
+
+func (i instrumentedLocalDaysCalculator) AddLocalDays(timestamp time.Time, number int) time.Time {
+	return i.timeCall("AddLocalDays", timestamp, number, func() time.Time { return i.calculator.AddLocalDays(timestamp, number) })
+}
+
+func (i instrumentedLocalDaysCalculator) StartOfLocalDay(timestamp time.Time) time.Time {
+	return i.timeCall("StartOfLocalDay", timestamp, nil, func() time.Time { return i.calculator.StartOfLocalDay(timestamp) })
+}
+
+func (i instrumentedLocalDaysCalculator) StartOfNextLocalDay(timestamp time.Time) time.Time {
+	return i.timeCall("StartOfNextLocalDay", timestamp, nil, func() time.Time { return i.calculator.StartOfNextLocalDay(timestamp) })
+}
+
+func (i instrumentedLocalDaysCalculator) StartOfLocalMonth(timestamp time.Time) time.Time {
+	return i.timeCall("StartOfLocalMonth", timestamp, nil, func() time.Time { return i.calculator.StartOfLocalMonth(timestamp) })
+}
+
+func (i instrumentedLocalDaysCalculator) StartOfNextLocalMonth(timestamp time.Time) time.Time {
+	return i.timeCall("StartOfNextLocalMonth", timestamp, nil, func() time.Time { return i.calculator.StartOfNextLocalMonth(timestamp) })
+}
+
+func (i instrumentedLocalDaysCalculator) GetLocalWeekday(timestamp time.Time) time.Weekday {
+	if !i.sampled() {
+		return i.calculator.GetLocalWeekday(timestamp)
+	}
+	start := time.Now()
+	result := i.calculator.GetLocalWeekday(timestamp)
+	i.hook.OnCall(Call{Method: "GetLocalWeekday", ZoneName: i.zoneName, Timestamp: timestamp, Result: result, Duration: time.Since(start)})
+	return result
+}
+
+func (i instrumentedLocalDaysCalculator) NextLocalWeekday(timestamp time.Time, weekday time.Weekday) time.Time {
+	return i.timeCall("NextLocalWeekday", timestamp, weekday, func() time.Time { return i.calculator.NextLocalWeekday(timestamp, weekday) })
+}
+
+func (i instrumentedLocalDaysCalculator) IsLocalMidnight(timestamp time.Time) bool {
+	return i.boolCall("IsLocalMidnight", timestamp, func() bool { return i.calculator.IsLocalMidnight(timestamp) })
+}
+
+func (i instrumentedLocalDaysCalculator) StartOfLocalWeek(timestamp time.Time) time.Time {
+	return i.timeCall("StartOfLocalWeek", timestamp, nil, func() time.Time { return startOfLocalWeek(i.calculator, timestamp) })
+}
+
+func (i instrumentedLocalDaysCalculator) StartOfNextLocalWeek(timestamp time.Time) time.Time {
+	return i.timeCall("StartOfNextLocalWeek", timestamp, nil, func() time.Time { return startOfNextLocalWeek(i.calculator, timestamp) })
+}
+
+func (i instrumentedLocalDaysCalculator) IsLocalHoliday(timestamp time.Time) bool {
+	return i.boolCall("IsLocalHoliday", timestamp, func() bool { return isLocalHoliday(i.calculator, timestamp) })
+}
+
+func (i instrumentedLocalDaysCalculator) IsLocalBusinessDay(timestamp time.Time) bool {
+	return i.boolCall("IsLocalBusinessDay", timestamp, func() bool { return isLocalBusinessDay(i.calculator, timestamp) })
+}
+
+func (i instrumentedLocalDaysCalculator) Now() time.Time {
+	return now(i.calculator)
+}
+
+func (i instrumentedLocalDaysCalculator) Location() *time.Location {
+	return Location(i.calculator)
+}
+
+func (i instrumentedLocalDaysCalculator) ZoneName() string {
+	return ZoneName(i.calculator)
+}
+
+func (i instrumentedLocalDaysCalculator) checkInput(timestamp time.Time) error {
+	return checkInput(i.calculator, timestamp)
+}
+
+func (i instrumentedLocalDaysCalculator) TZDataVersion() string {
+	return TZDataVersion(i.calculator)
+}
+
+func (i instrumentedLocalDaysCalculator) Spec() (CalculatorSpec, error) {
+	return SpecOf(i.calculator)
+}
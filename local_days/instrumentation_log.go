@@ -0,0 +1,9 @@
+//go:build !go1.21
+// +build !go1.21
+
+package local_days
+
+// defaultCallHook ignores the calls, because there are no log levels before log/slog (Go 1.21) and printing every call would flood the log. Use NewLogCallHook to print them anyway.
+func defaultCallHook() CallHook {
+	return CallHookFunc(func(Call) {})
+}
@@ -0,0 +1,25 @@
+//go:build !go1.21
+// +build !go1.21
+
+package local_days_test
+
+import (
+	"bytes"
+	"log"
+	"time"
+
+	"github.com/corbym/gocrest/is"
+	"github.com/corbym/gocrest/then"
+	"github.com/hochfrequenz/go-local-days/local_days"
+)
+
+// Test_Default_Call_Hook_Before_Slog tests that the default hook does not print the calls if log/slog is not available.
+func (s *Suite) Test_Default_Call_Hook_Before_Slog() {
+	var output bytes.Buffer
+	previous := log.Writer()
+	log.SetOutput(&output)
+	defer log.SetOutput(previous)
+	instrumented := local_days.NewInstrumentedLocalDaysCalculator(local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin"), nil)
+	instrumented.StartOfLocalDay(time.Date(2022, 11, 16, 12, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), output.Len(), is.EqualTo(0))
+}
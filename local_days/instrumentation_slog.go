@@ -0,0 +1,38 @@
+//go:build go1.21
+// +build go1.21
+
+package local_days
+
+import (
+	"context"
+	"log/slog"
+)
+
+// NewSlogCallHook returns a CallHook that logs every call using logger at the given level. If logger is nil, the current slog.Default() is used for each call.
+func NewSlogCallHook(logger *slog.Logger, level slog.Level) CallHook {
+	return CallHookFunc(func(call Call) {
+		logger := logger
+		if logger == nil {
+			logger = slog.Default()
+		}
+		if !logger.Enabled(context.Background(), level) {
+			return
+		}
+		attributes := []slog.Attr{
+			slog.String("method", call.Method),
+			slog.String("zone", call.ZoneName),
+			slog.Time("timestamp", call.Timestamp),
+			slog.Any("result", call.Result),
+			slog.Duration("duration", call.Duration),
+		}
+		if call.Argument != nil {
+			attributes = append(attributes, slog.Any("argument", call.Argument))
+		}
+		logger.LogAttrs(context.Background(), level, "local_days call", attributes...)
+	})
+}
+
+// defaultCallHook logs the calls using the default slog logger at debug level.
+func defaultCallHook() CallHook {
+	return NewSlogCallHook(nil, slog.LevelDebug)
+}
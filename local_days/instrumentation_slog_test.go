@@ -0,0 +1,33 @@
+//go:build go1.21
+// +build go1.21
+
+package local_days_test
+
+import (
+	"bytes"
+	"log/slog"
+	"time"
+
+	"github.com/corbym/gocrest/is"
+	"github.com/corbym/gocrest/then"
+	"github.com/hochfrequenz/go-local-days/local_days"
+)
+
+// Test_Slog_Call_Hook tests that calls are logged as structured slog records.
+func (s *Suite) Test_Slog_Call_Hook() {
+	var output bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&output, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	instrumented := local_days.NewInstrumentedLocalDaysCalculator(local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin"), local_days.NewSlogCallHook(logger, slog.LevelDebug))
+	instrumented.AddLocalDays(time.Date(2022, 11, 16, 12, 0, 0, 0, time.UTC), 1)
+	then.AssertThat(s.T(), output.String(), is.ValueContaining("level=DEBUG msg=\"local_days call\" method=AddLocalDays zone=Europe/Berlin timestamp=2022-11-16T12:00:00.000Z result=2022-11-17T12:00:00.000Z"))
+	then.AssertThat(s.T(), output.String(), is.ValueContaining("argument=1"))
+}
+
+// Test_Slog_Call_Hook_Disabled_Level tests that nothing is logged if the level is disabled.
+func (s *Suite) Test_Slog_Call_Hook_Disabled_Level() {
+	var output bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&output, nil))
+	instrumented := local_days.NewInstrumentedLocalDaysCalculator(local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin"), local_days.NewSlogCallHook(logger, slog.LevelDebug))
+	instrumented.StartOfLocalDay(time.Date(2022, 11, 16, 12, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), output.Len(), is.EqualTo(0))
+}
@@ -0,0 +1,23 @@
+package local_days
+
+import (
+	"time"
+)
+
+// Interval is the half-open time interval [Start, End), e.g. a local day from its start (inclusive) to the start of the next local day (exclusive). By the conventions of this package Start and End are in UTC.
+type Interval struct {
+	// Start is the first point in time that belongs to the interval
+	Start time.Time
+	// End is the first point in time after the interval
+	End time.Time
+}
+
+// Duration returns the length of the interval, e.g. 23h, 24h or 25h for local days in Germany.
+func (i Interval) Duration() time.Duration {
+	return i.End.Sub(i.Start)
+}
+
+// Contains returns true if and only if timestamp is within [Start, End).
+func (i Interval) Contains(timestamp time.Time) bool {
+	return !timestamp.Before(i.Start) && timestamp.Before(i.End)
+}
@@ -0,0 +1,159 @@
+//go:build go1.23
+// +build go1.23
+
+package local_days
+
+import (
+	"fmt"
+	"iter"
+	"log"
+	"time"
+)
+
+// LocalDaysIterator is an optional interface of LocalDaysCalculators that iterate over local periods themselves. The calculators of this package implement it.
+// The package functions LocalDays, LocalDayIntervals, LocalMonths, LocalWeekdays and Slots use it if the calculator implements it and fall back to the methods of LocalDaysCalculator otherwise, so you only need to implement it if your calculator can iterate faster.
+// Like the iterators, it's only available with Go 1.23+ (because of the iter package).
+type LocalDaysIterator interface {
+	// LocalDays is like the package function LocalDays.
+	LocalDays(from time.Time, to time.Time) iter.Seq[time.Time]
+	// LocalDayIntervals is like the package function LocalDayIntervals.
+	LocalDayIntervals(from time.Time, to time.Time) iter.Seq[Interval]
+	// LocalMonths is like the package function LocalMonths.
+	LocalMonths(from time.Time, to time.Time) iter.Seq[time.Time]
+	// LocalWeekdays is like the package function LocalWeekdays.
+	LocalWeekdays(from time.Time, to time.Time, weekday time.Weekday) iter.Seq[time.Time]
+	// Slots is like the package function Slots.
+	Slots(from time.Time, to time.Time, length time.Duration) iter.Seq[Interval]
+}
+
+// LocalDays returns the starts of all local days that start within [from, to), so that loops over local days become
+//
+//	for startOfDay := range local_days.LocalDays(calculator, from, to) { ... }
+func LocalDays(calculator LocalDaysCalculator, from time.Time, to time.Time) iter.Seq[time.Time] {
+	if iterator, ok := calculator.(LocalDaysIterator); ok {
+		return iterator.LocalDays(from, to)
+	}
+	return localDays(calculator, from, to)
+}
+
+func localDays(calculator LocalDaysCalculator, from time.Time, to time.Time) iter.Seq[time.Time] {
+	return periodStarts(calculator.StartOfLocalDay, calculator.StartOfNextLocalDay, from, to)
+}
+
+// LocalDayIntervals returns all local days that start within [from, to) as intervals from their start to the start of the next local day.
+func LocalDayIntervals(calculator LocalDaysCalculator, from time.Time, to time.Time) iter.Seq[Interval] {
+	if iterator, ok := calculator.(LocalDaysIterator); ok {
+		return iterator.LocalDayIntervals(from, to)
+	}
+	return localDayIntervals(calculator, from, to)
+}
+
+func localDayIntervals(calculator LocalDaysCalculator, from time.Time, to time.Time) iter.Seq[Interval] {
+	return func(yield func(Interval) bool) {
+		for startOfDay := range localDays(calculator, from, to) {
+			if !yield(Interval{Start: startOfDay, End: calculator.StartOfNextLocalDay(startOfDay)}) {
+				return
+			}
+		}
+	}
+}
+
+// LocalMonths returns the starts of all local months that start within [from, to).
+func LocalMonths(calculator LocalDaysCalculator, from time.Time, to time.Time) iter.Seq[time.Time] {
+	if iterator, ok := calculator.(LocalDaysIterator); ok {
+		return iterator.LocalMonths(from, to)
+	}
+	return localMonths(calculator, from, to)
+}
+
+func localMonths(calculator LocalDaysCalculator, from time.Time, to time.Time) iter.Seq[time.Time] {
+	return periodStarts(calculator.StartOfLocalMonth, calculator.StartOfNextLocalMonth, from, to)
+}
+
+// LocalWeekdays returns the starts of all local days with the given weekday that start within [from, to). It panics if weekday is not a valid time.Weekday.
+func LocalWeekdays(calculator LocalDaysCalculator, from time.Time, to time.Time, weekday time.Weekday) iter.Seq[time.Time] {
+	if iterator, ok := calculator.(LocalDaysIterator); ok {
+		return iterator.LocalWeekdays(from, to, weekday)
+	}
+	return localWeekdays(calculator, from, to, weekday)
+}
+
+func localWeekdays(calculator LocalDaysCalculator, from time.Time, to time.Time, weekday time.Weekday) iter.Seq[time.Time] {
+	if err := validateWeekday("LocalWeekdays", weekday); err != nil {
+		log.Panic(err)
+	}
+	return func(yield func(time.Time) bool) {
+		startOfDay := calculator.StartOfLocalDay(from)
+		if startOfDay.Before(from) || calculator.GetLocalWeekday(startOfDay) != weekday {
+			startOfDay = calculator.NextLocalWeekday(from, weekday)
+		}
+		for ; startOfDay.Before(to); startOfDay = calculator.NextLocalWeekday(startOfDay, weekday) {
+			if !yield(startOfDay) {
+				return
+			}
+		}
+	}
+}
+
+// Slots returns the consecutive slots of the given length (e.g. 15*time.Minute for quarter hours) of all local days that start within [from, to). The slots are aligned to the start of each local day.
+// If the length of a local day is not a multiple of length, its last slot ends early at the start of the next local day. It panics if length is not positive.
+func Slots(calculator LocalDaysCalculator, from time.Time, to time.Time, length time.Duration) iter.Seq[Interval] {
+	if iterator, ok := calculator.(LocalDaysIterator); ok {
+		return iterator.Slots(from, to, length)
+	}
+	return slots(calculator, from, to, length)
+}
+
+func slots(calculator LocalDaysCalculator, from time.Time, to time.Time, length time.Duration) iter.Seq[Interval] {
+	if length <= 0 {
+		log.Panic(fmt.Errorf("The slot length %s is not positive", length))
+	}
+	return func(yield func(Interval) bool) {
+		for day := range localDayIntervals(calculator, from, to) {
+			for start := day.Start; start.Before(day.End); start = start.Add(length) {
+				end := start.Add(length)
+				if end.After(day.End) {
+					end = day.End
+				}
+				if !yield(Interval{Start: start, End: end}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// periodStarts returns the starts of all periods that start within [from, to).
+func periodStarts(startOfPeriod func(time.Time) time.Time, startOfNextPeriod func(time.Time) time.Time, from time.Time, to time.Time) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		start := startOfPeriod(from)
+		if start.Before(from) {
+			start = startOfNextPeriod(from)
+		}
+		for ; start.Before(to); start = startOfNextPeriod(start) {
+			if !yield(start) {
+				return
+			}
+		}
+	}
+}
+
+func (c converterBasedLocalDaysCalculator) LocalDays(from time.Time, to time.Time) iter.Seq[time.Time] {
+	return localDays(c, from, to)
+}
+
+func (c converterBasedLocalDaysCalculator) LocalDayIntervals(from time.Time, to time.Time) iter.Seq[Interval] {
+	return localDayIntervals(c, from, to)
+}
+
+func (c converterBasedLocalDaysCalculator) LocalMonths(from time.Time, to time.Time) iter.Seq[time.Time] {
+	return localMonths(c, from, to)
+}
+
+func (c converterBasedLocalDaysCalculator) LocalWeekdays(from time.Time, to time.Time, weekday time.Weekday) iter.Seq[time.Time] {
+	return localWeekdays(c, from, to, weekday)
+}
+
+func (c converterBasedLocalDaysCalculator) Slots(from time.Time, to time.Time, length time.Duration) iter.Seq[Interval] {
+	return slots(c, from, to, length)
+}
@@ -0,0 +1,119 @@
+//go:build go1.23
+// +build go1.23
+
+package local_days_test
+
+import (
+	"time"
+
+	"github.com/corbym/gocrest/is"
+	"github.com/corbym/gocrest/then"
+	"github.com/hochfrequenz/go-local-days/local_days"
+)
+
+// Test_Iterate_Local_Days tests that all local days within the range are produced, including the short and long ones.
+func (s *Suite) Test_Iterate_Local_Days() {
+	berlin := local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin")
+	from, to := time.Date(2021, 12, 31, 23, 0, 0, 0, time.UTC), time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC)
+	var days []time.Time
+	for startOfDay := range local_days.LocalDays(berlin, from, to) {
+		days = append(days, startOfDay)
+	}
+	then.AssertThat(s.T(), len(days), is.EqualTo(365))
+	then.AssertThat(s.T(), days[0], is.EqualTo(from))
+	then.AssertThat(s.T(), days[364], is.EqualTo(time.Date(2022, 12, 30, 23, 0, 0, 0, time.UTC)))
+	durations := map[time.Duration]int{}
+	for day := range local_days.LocalDayIntervals(berlin, from, to) {
+		durations[day.Duration()]++
+	}
+	then.AssertThat(s.T(), durations, is.EqualTo(map[time.Duration]int{23 * time.Hour: 1, 24 * time.Hour: 363, 25 * time.Hour: 1}))
+	// a range that starts within a local day does not include that local day
+	var firstDay time.Time
+	for startOfDay := range local_days.LocalDays(berlin, from.Add(time.Nanosecond), to) {
+		firstDay = startOfDay
+		break
+	}
+	then.AssertThat(s.T(), firstDay, is.EqualTo(time.Date(2022, 1, 1, 23, 0, 0, 0, time.UTC)))
+}
+
+// Test_Iterate_Local_Months tests that all local months within the range are produced.
+func (s *Suite) Test_Iterate_Local_Months() {
+	berlin := local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin")
+	var months []time.Time
+	for startOfMonth := range local_days.LocalMonths(berlin, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		months = append(months, startOfMonth)
+	}
+	// January 2022 starts before, January 2023 within the range
+	then.AssertThat(s.T(), len(months), is.EqualTo(12))
+	then.AssertThat(s.T(), months[0], is.EqualTo(time.Date(2022, 1, 31, 23, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), months[2], is.EqualTo(time.Date(2022, 3, 31, 22, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), months[11], is.EqualTo(time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC)))
+}
+
+// Test_Iterate_Local_Weekdays tests that all occurrences of a weekday are produced.
+func (s *Suite) Test_Iterate_Local_Weekdays() {
+	berlin := local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin")
+	var mondays []time.Time
+	for monday := range local_days.LocalWeekdays(berlin, time.Date(2021, 12, 31, 23, 0, 0, 0, time.UTC), time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC), time.Monday) {
+		then.AssertThat(s.T(), berlin.GetLocalWeekday(monday), is.EqualTo(time.Monday))
+		mondays = append(mondays, monday)
+	}
+	then.AssertThat(s.T(), len(mondays), is.EqualTo(52))
+	then.AssertThat(s.T(), mondays[0], is.EqualTo(time.Date(2022, 1, 2, 23, 0, 0, 0, time.UTC)))
+	var saturdays int
+	for range local_days.LocalWeekdays(berlin, time.Date(2022, 1, 1, 23, 0, 0, 0, time.UTC), time.Date(2022, 1, 8, 23, 0, 0, 0, time.UTC), time.Saturday) {
+		saturdays++
+	}
+	then.AssertThat(s.T(), saturdays, is.EqualTo(1)) // 2022-01-08 starts at the end of the range
+	s.Panics(func() { local_days.LocalWeekdays(berlin, time.Time{}, time.Time{}, time.Weekday(7)) })
+}
+
+// Test_Iterate_Slots tests that quarter hour slots respect the DST switch.
+func (s *Suite) Test_Iterate_Slots() {
+	berlin := local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin")
+	var slots []local_days.Interval
+	for slot := range local_days.Slots(berlin, time.Date(2022, 2, 28, 23, 0, 0, 0, time.UTC), time.Date(2022, 3, 31, 22, 0, 0, 0, time.UTC), 15*time.Minute) {
+		slots = append(slots, slot)
+	}
+	then.AssertThat(s.T(), len(slots), is.EqualTo(31*96-4))
+	then.AssertThat(s.T(), slots[0], is.EqualTo(local_days.Interval{Start: time.Date(2022, 2, 28, 23, 0, 0, 0, time.UTC), End: time.Date(2022, 2, 28, 23, 15, 0, 0, time.UTC)}))
+	then.AssertThat(s.T(), slots[len(slots)-1].End, is.EqualTo(time.Date(2022, 3, 31, 22, 0, 0, 0, time.UTC)))
+	for i := 1; i < len(slots); i++ {
+		then.AssertThat(s.T(), slots[i].Start, is.EqualTo(slots[i-1].End))
+	}
+	// gas days of 25h are not a multiple of 2h, so the last slot ends early
+	gasDays := local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin", local_days.WithDayStart(6*time.Hour))
+	var lastSlot local_days.Interval
+	for slot := range local_days.Slots(gasDays, time.Date(2022, 10, 29, 4, 0, 0, 0, time.UTC), time.Date(2022, 10, 30, 5, 0, 0, 0, time.UTC), 2*time.Hour) {
+		lastSlot = slot
+	}
+	then.AssertThat(s.T(), lastSlot.Duration(), is.EqualTo(time.Hour))
+	s.Panics(func() { local_days.Slots(berlin, time.Time{}, time.Time{}, 0) })
+}
+
+// Test_Local_Days_Iterator tests that the calculators of the package iterate themselves and that the package functions fall back to the LocalDaysCalculator methods for other calculators.
+func (s *Suite) Test_Local_Days_Iterator() {
+	berlin := local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin")
+	iterator, ok := berlin.(local_days.LocalDaysIterator)
+	then.AssertThat(s.T(), ok, is.True())
+	from, to := time.Date(2022, 3, 20, 23, 0, 0, 0, time.UTC), time.Date(2022, 4, 3, 22, 0, 0, 0, time.UTC)
+	var methodDays, fallbackDays []time.Time
+	for startOfDay := range iterator.LocalDays(from, to) {
+		methodDays = append(methodDays, startOfDay)
+	}
+	for startOfDay := range local_days.LocalDays(minimalCalculator{berlin}, from, to) {
+		fallbackDays = append(fallbackDays, startOfDay)
+	}
+	then.AssertThat(s.T(), len(methodDays), is.EqualTo(14))
+	then.AssertThat(s.T(), methodDays, is.EqualTo(fallbackDays))
+	var methodSlots, fallbackSlots int
+	for range iterator.Slots(from, to, time.Hour) {
+		methodSlots++
+	}
+	for range local_days.Slots(minimalCalculator{berlin}, from, to, time.Hour) {
+		fallbackSlots++
+	}
+	then.AssertThat(s.T(), methodSlots, is.EqualTo(14*24-1))
+	then.AssertThat(s.T(), fallbackSlots, is.EqualTo(methodSlots))
+	s.Panics(func() { iterator.LocalWeekdays(from, to, time.Weekday(-1)) })
+}
@@ -0,0 +1,65 @@
+package local_days
+
+import (
+	"time"
+)
+
+// offsetTransitionStep is the interval in which OffsetTransitions samples the UTC offset. No zone in the tzdata changes its offset twice within this interval.
+const offsetTransitionStep = 6 * time.Hour
+
+// OffsetTransitions returns the points in time (as UTC) in (from, to] at which the UTC offset of location changes, in chronological order.
+// It samples the offset every 6 hours and searches for the exact second in between, so it misses transitions that are reverted within less than 6 hours (which don't exist in the tzdata).
+func OffsetTransitions(location *time.Location, from time.Time, to time.Time) []time.Time {
+	c := converterBasedLocalDaysCalculator{converter: locationBasedLocalTimeConverter{location: location}}
+	var transitions []time.Time
+	for before := from; before.Before(to); before = before.Add(offsetTransitionStep) {
+		after := before.Add(offsetTransitionStep)
+		if after.After(to) {
+			after = to
+		}
+		if offsetBefore := c.offsetAt(before); c.offsetAt(after) != offsetBefore {
+			transitions = append(transitions, c.offsetTransition(before, after, offsetBefore))
+		}
+	}
+	return transitions
+}
+
+// LocalClockTimes returns the points in time (as UTC) at which the local clock in location shows clock (the time since midnight, which may be 24h or more) on the local date (given as midnight UTC, see LocalDate), in chronological order.
+// Usually there is exactly one. If the clock time is repeated because the clocks are set back, there are two. If the clock time is skipped because the clocks are set forward, the only one is the point in time at which the clocks pass it.
+// So the first one is always the point in time at which the local clock time starts.
+func LocalClockTimes(location *time.Location, date time.Time, clock time.Duration) []time.Time {
+	c := converterBasedLocalDaysCalculator{converter: locationBasedLocalTimeConverter{location: location}}
+	wall := date.Add(clock)
+	// we assume that there is at most one UTC offset transition within a day around the clock time
+	offsetBefore, offsetAfter := c.offsetAt(wall.Add(-24*time.Hour)), c.offsetAt(wall.Add(24*time.Hour))
+	if offsetBefore == offsetAfter {
+		return []time.Time{atOffset(wall, offsetBefore)}
+	}
+	var times []time.Time
+	// the offset from before the transition leads to the earlier point in time if the clocks are set back
+	for _, offset := range []int{offsetBefore, offsetAfter} {
+		if candidate := atOffset(wall, offset); wallClock(c.toLocalTime(candidate)).Equal(wall) {
+			times = append(times, candidate)
+		}
+	}
+	if len(times) > 0 {
+		return times
+	}
+	// the clock time is skipped, so the transition is between the clock time interpreted with the offset after and with the offset before
+	return []time.Time{c.offsetTransition(atOffset(wall, offsetAfter), atOffset(wall, offsetBefore), offsetBefore)}
+}
+
+// offsetTransition returns the first whole second in (before, after] at which the UTC offset differs from offsetBefore. The offset has to differ at after.
+func (c converterBasedLocalDaysCalculator) offsetTransition(before time.Time, after time.Time, offsetBefore int) time.Time {
+	// transitions happen at whole seconds, so the offsets at the seconds before and after are the same as at before and after
+	low, high := before.Unix(), after.Unix()
+	for high-low > 1 {
+		middle := low + (high-low)/2
+		if c.offsetAt(time.Unix(middle, 0)) == offsetBefore {
+			low = middle
+		} else {
+			high = middle
+		}
+	}
+	return time.Unix(high, 0).UTC()
+}
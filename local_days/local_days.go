@@ -1,41 +1,209 @@
 // Package local_days encapsulate the logic to convert to local date times behind an interface (LocalDaysCalculator) and provides a straight forward implementation NewTimeZoneBasedLocalTimeConverter.
+// If you need a time model that is not based on a time.Location, implement a ToLocalTimeConverter and use NewConverterBasedLocalDaysCalculator.
 package local_days
 
 import (
 	"fmt"
 	"log"
+	"math"
+	"strings"
 	"time"
 )
 
-// NewTimeZoneBasedLocalTimeConverter returns a ToLocalTimeConverter that internally uses the timezone data from the timezone with the given zoneName (e.g. "Europe/Berlin"). It requires the tzdata to be available on the system and will panic if this is not the case.
-func NewTimeZoneBasedLocalTimeConverter(zoneName string) LocalDaysCalculator {
+// NewTimeZoneBasedLocalTimeConverter returns a LocalDaysCalculator that internally uses the timezone data from the timezone with the given zoneName (e.g. "Europe/Berlin"). It requires the tzdata to be available on the system and will panic if this is not the case.
+func NewTimeZoneBasedLocalTimeConverter(zoneName string, options ...Option) LocalDaysCalculator {
+	calculator, err := newTimeZoneBasedLocalDaysCalculator(zoneName, options)
+	if err != nil {
+		log.Panic(err)
+	}
+	return calculator
+}
+
+func newTimeZoneBasedLocalDaysCalculator(zoneName string, options []Option) (converterBasedLocalDaysCalculator, error) {
 	location, err := time.LoadLocation(zoneName)
 	if err != nil {
-		errorMsg := fmt.Errorf("The timezone data for '%s' could not be found. Import \"time/tzdata\" anywhere in your project or build with `-tags timetzdata`: https://pkg.go.dev/time/tzdata", zoneName)
-		log.Panic(errorMsg)
+		return converterBasedLocalDaysCalculator{}, &ZoneNotFoundError{ZoneName: zoneName, Err: err}
 	}
-	return locationBasedLocalTimeConverter{location: location}
+	calculator := newConverterBasedLocalDaysCalculator(locationBasedLocalTimeConverter{location: location}, systemTZDataVersion(), options)
+	calculator.zoneSpec = &CalculatorSpec{ZoneName: zoneName}
+	return calculator, nil
 }
 
-type locationBasedLocalTimeConverter struct {
-	location *time.Location
+// NewVersionedTimeZoneBasedLocalTimeConverter returns a LocalDaysCalculator for the zone with the given zoneName (e.g. "Europe/Berlin") whose rules are taken from location, which has been loaded from the given release of the tzdata (e.g. "2024a"), e.g. by the package embedded_tzdata.
+// Other than NewLocationBasedLocalTimeConverter, the calculator reports the release in TZDataVersion and is described by a CalculatorSpec with the zoneName (see SpecOf). It panics if location is nil.
+func NewVersionedTimeZoneBasedLocalTimeConverter(zoneName string, location *time.Location, tzdataVersion string, options ...Option) LocalDaysCalculator {
+	if location == nil {
+		log.Panic(fmt.Errorf("The location must not be nil"))
+	}
+	calculator := newConverterBasedLocalDaysCalculator(locationBasedLocalTimeConverter{location: location}, tzdataVersion, options)
+	calculator.zoneSpec = &CalculatorSpec{ZoneName: zoneName}
+	return calculator
+}
+
+// NewLocationBasedLocalTimeConverter returns a LocalDaysCalculator that uses the given location, e.g. one that you've already loaded using time.LoadLocation. It panics if location is nil.
+func NewLocationBasedLocalTimeConverter(location *time.Location, options ...Option) LocalDaysCalculator {
+	if location == nil {
+		log.Panic(fmt.Errorf("The location must not be nil"))
+	}
+	return newLocationBasedLocalDaysCalculator(location, "", options)
+}
+
+// NewTZDataBasedLocalTimeConverter returns a LocalDaysCalculator that uses the timezone described by data, which has to be the content of an IANA timezone database file in TZif format (e.g. "/usr/share/zoneinfo/Europe/Berlin").
+// This allows you to pin a specific version of the tzdata instead of relying on the tzdata available on the system. The zoneName is only used as the name of the location (see time.LoadLocationFromTZData).
+func NewTZDataBasedLocalTimeConverter(zoneName string, data []byte, options ...Option) (LocalDaysCalculator, error) {
+	location, err := time.LoadLocationFromTZData(zoneName, data)
+	if err != nil {
+		return nil, fmt.Errorf("The TZif data for '%s' could not be loaded: %w", zoneName, err)
+	}
+	return newLocationBasedLocalDaysCalculator(location, "", options), nil
+}
+
+// NewPOSIXTZBasedLocalTimeConverter returns a LocalDaysCalculator that uses the rules of the given POSIX TZ string for all points in time, e.g. "CET-1CEST,M3.5.0,M10.5.0/3" for the current rules in Germany or "CET-1" for Germany without daylight saving time.
+// Other than the zone based calculators it does not know about any historic rule changes. The format is described in https://pubs.opengroup.org/onlinepubs/9699919799/basedefs/V1_chap08.html (with the extensions of RFC 8536); it returns an error if tz is invalid.
+func NewPOSIXTZBasedLocalTimeConverter(tz string, options ...Option) (LocalDaysCalculator, error) {
+	location, err := loadLocationFromPOSIXTZ(tz)
+	if err != nil {
+		return nil, fmt.Errorf("The POSIX TZ string '%s' is invalid: %w", tz, err)
+	}
+	return newLocationBasedLocalDaysCalculator(location, "", options), nil
+}
+
+// NewFixedOffsetLocalTimeConverter returns a LocalDaysCalculator for a local time that is always offset (east of UTC) from UTC, without any daylight saving time, e.g. time.Hour for contracts that are in CET/MEZ all year.
+// It panics if the offset is not a whole number of seconds or not between -24h and +24h.
+func NewFixedOffsetLocalTimeConverter(offset time.Duration, options ...Option) LocalDaysCalculator {
+	calculator, err := newFixedOffsetLocalDaysCalculator(offset, options)
+	if err != nil {
+		log.Panic(err)
+	}
+	return calculator
+}
+
+func newFixedOffsetLocalDaysCalculator(offset time.Duration, options []Option) (converterBasedLocalDaysCalculator, error) {
+	if offset%time.Second != 0 || offset <= -24*time.Hour || offset >= 24*time.Hour {
+		return converterBasedLocalDaysCalculator{}, fmt.Errorf("The offset %s is not a whole number of seconds between -24h and +24h", offset)
+	}
+	name := fixedOffsetZoneName(offset)
+	calculator := newConverterBasedLocalDaysCalculator(locationBasedLocalTimeConverter{location: time.FixedZone(name, int(offset/time.Second))}, "", options)
+	calculator.zoneSpec = &CalculatorSpec{FixedOffset: strings.TrimPrefix(name, "UTC")}
+	return calculator, nil
+}
+
+// fixedOffsetZoneName returns a name like "UTC+01:00" for a fixed offset from UTC.
+func fixedOffsetZoneName(offset time.Duration) string {
+	if offset < 0 {
+		return "UTC-" + formatClock(-offset)
+	}
+	return "UTC+" + formatClock(offset)
+}
+
+// formatClock formats a non-negative duration of less than 24h like a clock, i.e. "hh:mm" or "hh:mm:ss" if the seconds are not zero.
+func formatClock(duration time.Duration) string {
+	clock := fmt.Sprintf("%02d:%02d", duration/time.Hour, duration%time.Hour/time.Minute)
+	if seconds := duration % time.Minute / time.Second; seconds != 0 {
+		clock += fmt.Sprintf(":%02d", seconds)
+	}
+	return clock
+}
+
+// NewConverterBasedLocalDaysCalculator returns a LocalDaysCalculator that bases all its calculations on the local times returned by converter.
+// This way custom (or legacy) time models get all the logic for local days and months for free. It panics if converter is nil.
+func NewConverterBasedLocalDaysCalculator(converter ToLocalTimeConverter, options ...Option) LocalDaysCalculator {
+	if converter == nil {
+		log.Panic(fmt.Errorf("The converter must not be nil"))
+	}
+	return newConverterBasedLocalDaysCalculator(converter, "", options)
+}
+
+func newLocationBasedLocalDaysCalculator(location *time.Location, tzdataVersion string, options []Option) LocalDaysCalculator {
+	return newConverterBasedLocalDaysCalculator(locationBasedLocalTimeConverter{location: location}, tzdataVersion, options)
+}
+
+func newConverterBasedLocalDaysCalculator(converter ToLocalTimeConverter, tzdataVersion string, options []Option) converterBasedLocalDaysCalculator {
+	calculator := converterBasedLocalDaysCalculator{
+		converter:     converter,
+		tzdataVersion: tzdataVersion,
+		weekStart:     time.Monday,
+		now:           time.Now,
+	}
+	for _, option := range options {
+		option(&calculator)
+	}
+	return calculator
 }
 
 // ToLocalTimeConverter contains a method to convert a time into a local time. This will, in most cases, happen on the basis of timezone data, but you are free to write your own conversion, although you're probably missing out on details at one point.
+// If the converter is based on a single time.Location, it should also implement LocationProvider.
 type ToLocalTimeConverter interface {
-	// toLocalTime converts a timestamp to a "local" time by adjusting date, time and UTC-offset. The actual point in time in UTC or Unix does _not_ change.
-	toLocalTime(timestamp time.Time) time.Time
+	// ToLocalTime converts a timestamp to a "local" time by adjusting date, time and UTC-offset. The actual point in time in UTC or Unix does _not_ change.
+	// The UTC offset of the returned time (see time.Time.Zone) has to be the offset of the local time at timestamp, because it's used to find the points in time at which local days start.
+	ToLocalTime(timestamp time.Time) time.Time
+}
+
+// LocationProvider is implemented by ToLocalTimeConverters and LocalDaysCalculators that are based on a single time.Location.
+type LocationProvider interface {
+	// Location returns the location whose local time is used.
+	Location() *time.Location
+	// ZoneName returns the name of the location (e.g. "Europe/Berlin") or an empty string if the location is nil.
+	ZoneName() string
+}
+
+type locationBasedLocalTimeConverter struct {
+	location *time.Location
 }
 
-func (l locationBasedLocalTimeConverter) toLocalTime(timestamp time.Time) time.Time {
+func (l locationBasedLocalTimeConverter) ToLocalTime(timestamp time.Time) time.Time {
 	return timestamp.In(l.location)
 }
 
+func (l locationBasedLocalTimeConverter) Location() *time.Location {
+	return l.location
+}
+
+func (l locationBasedLocalTimeConverter) ZoneName() string {
+	return l.location.String()
+}
+
+// startOfLocalDay returns the point in time (as UTC) at which the local wall clock shows dayStart (as offset from midnight) on the given date, using the UTC offset from the day before.
+// This is the start of the local day unless the UTC offset changes in between, because then the wall clock time might be skipped or ambiguous; in this case it returns false.
+func (l locationBasedLocalTimeConverter) startOfLocalDay(year int, month time.Month, day int, dayStart time.Duration) (time.Time, bool) {
+	wall := time.Date(year, month, day, 0, 0, 0, int(dayStart), time.UTC)
+	// UTC offsets are less than 16h, so this is more than a day before the start
+	_, offsetBefore := wall.Add(-48 * time.Hour).In(l.location).Zone()
+	start := atOffset(wall, offsetBefore)
+	_, offset := start.In(l.location).Zone()
+	return start, offset == offsetBefore
+}
+
+type converterBasedLocalDaysCalculator struct {
+	converter ToLocalTimeConverter
+	// tzdataVersion is the release of the tzdata the location of the converter is loaded from, if known (see TZDataVersion)
+	tzdataVersion string
+	// dayStart is the local time at which local days start, as offset from local midnight (see WithDayStart)
+	dayStart time.Duration
+	// weekStart is the first day of local weeks (see WithWeekStart)
+	weekStart time.Weekday
+	// strictInputValidation is true if timestamps that are not in UTC or carry a monotonic clock reading are rejected (see WithStrictInputValidation)
+	strictInputValidation bool
+	// holidays are the holidays considered by IsLocalHoliday and IsLocalBusinessDay; nil means there are none
+	holidays HolidayCalendar
+	// holidayRegion is the region the holidays are registered for, if they have been configured using WithHolidayRegion
+	holidayRegion string
+	// zoneSpec describes the time zone if the calculator has been created from a zone name or a fixed offset (see Spec)
+	zoneSpec *CalculatorSpec
+	// now returns the current time (see WithClock)
+	now func() time.Time
+}
+
+func (c converterBasedLocalDaysCalculator) toLocalTime(timestamp time.Time) time.Time {
+	return c.converter.ToLocalTime(timestamp)
+}
+
 // LocalDaysCalculator is an interface that encapsulates common date time operations that involve local date times.
 type LocalDaysCalculator interface {
-	// AddLocalDays converts timestamp to local time, then adds 1 day and returns UTC. This will effectively add 24h on 363 out of 365 cases. But on the days on which the calendar switches from Daylight saving time (DST) to "normal" time or vice versa it might add 25 or 23 hours.
+	// AddLocalDays converts timestamp to local time, then adds 1 day and returns UTC. This will effectively add 24h on 363 out of 365 cases. But on the days on which the calendar switches from Daylight saving time (DST) to "normal" time or vice versa it might add 25 or 23 hours. If the resulting local time does not exist (because the clocks are set forward), the result is shifted forward by the length of the gap.
 	AddLocalDays(timestamp time.Time, number int) time.Time
 	// StartOfLocalDay converts timestamp to local time, then sets hour, minute and seconds to 0 and returns as UTC. The return value is always <= the given timestamp.
+	// If the calculator is configured with a day start (see WithDayStart), local days start at that local time instead of midnight. This applies to all methods that deal with local days, weeks and months.
 	StartOfLocalDay(timestamp time.Time) time.Time
 	// StartOfNextLocalDay converts timestamp to local time, then returns the next start of local day (midnight, 00:00am local time) as UTC. The return value is always > the given timestamp.
 	StartOfNextLocalDay(timestamp time.Time) time.Time
@@ -45,56 +213,347 @@ type LocalDaysCalculator interface {
 	StartOfNextLocalMonth(timestamp time.Time) time.Time
 	// GetLocalWeekday returns the weekday of the given timestamp in local timezone.
 	GetLocalWeekday(timestamp time.Time) time.Weekday
-	// NextLocalWeekday returns the start of the next local weekday (as specified) in UTC. The result always > than the given timestamp. It might be up to 7 days later than the given timestamp. If e.g. providing a tuesday and requesting the next tuesday, the result will be the timestamp + 7 Local days
+	// NextLocalWeekday returns the start of the next local weekday (as specified) in UTC. The result always > than the given timestamp. It might be up to 7 days later than the given timestamp. If e.g. providing a tuesday and requesting the next tuesday, the result will be the timestamp + 7 Local days. It panics if weekday is not a valid time.Weekday.
 	NextLocalWeekday(timestamp time.Time, weekday time.Weekday) time.Time
-	// IsLocalMidnight returns true if and only if timestamp is midnight in local time
+	// IsLocalMidnight returns true if and only if timestamp is midnight in local time. On the rare days on which local midnight does not exist (because the clocks are set forward at midnight), the start of the local day counts as midnight. If local midnight occurs twice because the clocks are set back across it (e.g. "Antarctica/Casey" on 2010-03-05), both count as midnight, because the local date changes at both. Location and monotonic clock reading of timestamp do not matter.
 	IsLocalMidnight(timestamp time.Time) bool
 }
 
-// the following implementations are tested by the package "germany"
+// LocalWeeksCalculator is implemented by LocalDaysCalculators that know about local weeks. All calculators returned by this package implement it.
+type LocalWeeksCalculator interface {
+	// StartOfLocalWeek converts timestamp to local time, then returns the start of the local week as UTC. Weeks start on Monday unless configured otherwise (see WithWeekStart). The return value is always <= the given timestamp.
+	StartOfLocalWeek(timestamp time.Time) time.Time
+	// StartOfNextLocalWeek converts timestamp to local time, then returns the start of the next local week as UTC. The return value is always > the given timestamp.
+	StartOfNextLocalWeek(timestamp time.Time) time.Time
+}
 
-func (l locationBasedLocalTimeConverter) AddLocalDays(timestamp time.Time, number int) time.Time {
-	return l.toLocalTime(timestamp).AddDate(0, 0, number).UTC()
+// BusinessDaysCalculator is implemented by LocalDaysCalculators that know about holidays. All calculators returned by this package implement it.
+type BusinessDaysCalculator interface {
+	// IsLocalHoliday returns true if and only if the local day of timestamp is a holiday according to the HolidayCalendar of the calculator (see WithHolidayCalendar). Without a holiday calendar there are no holidays.
+	IsLocalHoliday(timestamp time.Time) bool
+	// IsLocalBusinessDay returns true if and only if the local day of timestamp is neither a Saturday, nor a Sunday, nor a holiday (see IsLocalHoliday).
+	IsLocalBusinessDay(timestamp time.Time) bool
 }
 
-func (l locationBasedLocalTimeConverter) StartOfLocalDay(timestamp time.Time) time.Time {
-	localTime := l.toLocalTime(timestamp)
-	localMidnight := time.Date(localTime.Year(), localTime.Month(), localTime.Day(), 0, 0, 0, 0, l.location)
-	return localMidnight.UTC()
+// Clock is implemented by LocalDaysCalculators that have a clock. All calculators returned by this package implement it.
+type Clock interface {
+	// Now returns the current time in UTC according to the clock of the calculator (see WithClock).
+	Now() time.Time
 }
 
-func (l locationBasedLocalTimeConverter) StartOfNextLocalDay(timestamp time.Time) time.Time {
-	plusOneDayInLocally := l.toLocalTime(timestamp).AddDate(0, 0, 1)
-	return l.StartOfLocalDay(plusOneDayInLocally.UTC())
+// startOfLocalWeek calls StartOfLocalWeek of calculator if it implements LocalWeeksCalculator. Otherwise, local weeks start on Monday.
+func startOfLocalWeek(calculator LocalDaysCalculator, timestamp time.Time) time.Time {
+	if weeks, ok := calculator.(LocalWeeksCalculator); ok {
+		return weeks.StartOfLocalWeek(timestamp)
+	}
+	startOfDay := calculator.StartOfLocalDay(timestamp)
+	for calculator.GetLocalWeekday(startOfDay) != time.Monday {
+		startOfDay = calculator.StartOfLocalDay(startOfDay.Add(-time.Nanosecond))
+	}
+	return startOfDay
 }
 
-func (l locationBasedLocalTimeConverter) StartOfLocalMonth(timestamp time.Time) time.Time {
-	localTime := l.toLocalTime(timestamp)
-	startOfLocalMonth := time.Date(localTime.Year(), localTime.Month(), 1, 0, 0, 0, 0, l.location)
-	return startOfLocalMonth.UTC()
+// startOfNextLocalWeek calls StartOfNextLocalWeek of calculator if it implements LocalWeeksCalculator. Otherwise, local weeks start on Monday.
+func startOfNextLocalWeek(calculator LocalDaysCalculator, timestamp time.Time) time.Time {
+	if weeks, ok := calculator.(LocalWeeksCalculator); ok {
+		return weeks.StartOfNextLocalWeek(timestamp)
+	}
+	return calculator.NextLocalWeekday(timestamp, time.Monday)
 }
 
-func (l locationBasedLocalTimeConverter) StartOfNextLocalMonth(timestamp time.Time) time.Time {
-	localTime := l.toLocalTime(timestamp)
-	startOfNextLocalMonth := time.Date(localTime.Year(), localTime.Month()+1, 1, 0, 0, 0, 0, l.location)
-	return startOfNextLocalMonth.UTC()
+// isLocalHoliday calls IsLocalHoliday of calculator if it implements BusinessDaysCalculator. Otherwise, there are no holidays.
+func isLocalHoliday(calculator LocalDaysCalculator, timestamp time.Time) bool {
+	if businessDays, ok := calculator.(BusinessDaysCalculator); ok {
+		return businessDays.IsLocalHoliday(timestamp)
+	}
+	return false
+}
+
+// isLocalBusinessDay calls IsLocalBusinessDay of calculator if it implements BusinessDaysCalculator. Otherwise, all days but Saturdays and Sundays are business days.
+func isLocalBusinessDay(calculator LocalDaysCalculator, timestamp time.Time) bool {
+	if businessDays, ok := calculator.(BusinessDaysCalculator); ok {
+		return businessDays.IsLocalBusinessDay(timestamp)
+	}
+	weekday := calculator.GetLocalWeekday(timestamp)
+	return weekday != time.Saturday && weekday != time.Sunday
 }
 
-func (l locationBasedLocalTimeConverter) GetLocalWeekday(timestamp time.Time) time.Weekday {
-	return l.toLocalTime(timestamp).Weekday()
+// now calls Now of calculator if it implements Clock. Otherwise, it returns the current system time in UTC.
+func now(calculator LocalDaysCalculator) time.Time {
+	if clock, ok := calculator.(Clock); ok {
+		return clock.Now()
+	}
+	return time.Now().UTC()
 }
 
-func (l locationBasedLocalTimeConverter) NextLocalWeekday(timestamp time.Time, weekday time.Weekday) time.Time {
-	localTime := l.StartOfNextLocalDay(timestamp).In(l.location)
-	for {
-		if localTime.Weekday() == weekday {
-			return localTime.UTC()
+// the following implementations are tested by the package "germany" and, for all IANA time zones, by the package "conformance"
+
+// maxDaysPerDuration is the number of days that (as 24h each) fit into a time.Duration.
+const maxDaysPerDuration = int(math.MaxInt64 / int64(24*time.Hour))
+
+func (c converterBasedLocalDaysCalculator) AddLocalDays(timestamp time.Time, number int) time.Time {
+	c.validateInput(timestamp)
+	offset := c.offsetAt(timestamp)
+	// usually the offset doesn't change, so the result is simply the same wall clock time some days later, i.e. 24h per day (as long as that fits into a time.Duration)
+	if number > -maxDaysPerDuration && number < maxDaysPerDuration {
+		if candidate := timestamp.UTC().Add(time.Duration(number) * 24 * time.Hour); c.offsetAt(candidate) == offset {
+			return candidate
 		}
-		localTime = localTime.AddDate(0, 0, 1)
 	}
+	return c.fromLocalWallClock(atOffset(timestamp.UTC(), -offset).AddDate(0, 0, number), offset)
+}
+
+func (c converterBasedLocalDaysCalculator) StartOfLocalDay(timestamp time.Time) time.Time {
+	c.validateInput(timestamp)
+	year, month, day := c.localDate(timestamp)
+	return c.startOfLocalDay(year, month, day)
+}
+
+func (c converterBasedLocalDaysCalculator) StartOfNextLocalDay(timestamp time.Time) time.Time {
+	c.validateInput(timestamp)
+	year, month, day := c.localDate(timestamp)
+	return c.startOfLocalDayAfter(timestamp, year, month, day+1)
+}
+
+func (c converterBasedLocalDaysCalculator) StartOfLocalMonth(timestamp time.Time) time.Time {
+	c.validateInput(timestamp)
+	year, month, _ := c.localDate(timestamp)
+	return c.startOfLocalDay(year, month, 1)
 }
 
-func (l locationBasedLocalTimeConverter) IsLocalMidnight(timestamp time.Time) bool {
-	localTime := l.toLocalTime(timestamp)
-	return localTime.UTC() == l.StartOfLocalDay(timestamp)
+func (c converterBasedLocalDaysCalculator) StartOfNextLocalMonth(timestamp time.Time) time.Time {
+	c.validateInput(timestamp)
+	year, month, _ := c.localDate(timestamp)
+	return c.startOfLocalDayAfter(timestamp, year, month+1, 1)
+}
+
+func (c converterBasedLocalDaysCalculator) GetLocalWeekday(timestamp time.Time) time.Weekday {
+	c.validateInput(timestamp)
+	return c.localDay(timestamp).Weekday()
+}
+
+func (c converterBasedLocalDaysCalculator) NextLocalWeekday(timestamp time.Time, weekday time.Weekday) time.Time {
+	c.validateInput(timestamp)
+	if err := validateWeekday("NextLocalWeekday", weekday); err != nil {
+		log.Panic(err)
+	}
+	day := c.localDay(timestamp)
+	for dayOfMonth := day.Day() + 1; ; dayOfMonth++ {
+		startOfDay := c.startOfLocalDayAfter(timestamp, day.Year(), day.Month(), dayOfMonth)
+		if c.GetLocalWeekday(startOfDay) == weekday {
+			return startOfDay
+		}
+	}
+}
+
+func (c converterBasedLocalDaysCalculator) IsLocalMidnight(timestamp time.Time) bool {
+	c.validateInput(timestamp)
+	return c.isStartOfLocalDay(timestamp, c.localDay(timestamp))
+}
+
+func (c converterBasedLocalDaysCalculator) StartOfLocalWeek(timestamp time.Time) time.Time {
+	c.validateInput(timestamp)
+	day := c.localDay(timestamp)
+	return c.startOfLocalDay(day.Year(), day.Month(), day.Day()-c.daysSinceStartOfWeek(day))
+}
+
+func (c converterBasedLocalDaysCalculator) StartOfNextLocalWeek(timestamp time.Time) time.Time {
+	c.validateInput(timestamp)
+	day := c.localDay(timestamp)
+	return c.startOfLocalDayAfter(timestamp, day.Year(), day.Month(), day.Day()-c.daysSinceStartOfWeek(day)+7)
+}
+
+// daysSinceStartOfWeek returns the number of days between the first day of the week and day (0-6).
+func (c converterBasedLocalDaysCalculator) daysSinceStartOfWeek(day time.Time) int {
+	return (int(day.Weekday()) - int(c.weekStart) + 7) % 7
+}
+
+func (c converterBasedLocalDaysCalculator) IsLocalHoliday(timestamp time.Time) bool {
+	c.validateInput(timestamp)
+	if c.holidays == nil {
+		return false
+	}
+	day := c.localDay(timestamp)
+	return c.holidays.IsHoliday(day.Year(), day.Month(), day.Day())
+}
+
+func (c converterBasedLocalDaysCalculator) IsLocalBusinessDay(timestamp time.Time) bool {
+	weekday := c.GetLocalWeekday(timestamp)
+	return weekday != time.Saturday && weekday != time.Sunday && !c.IsLocalHoliday(timestamp)
+}
+
+func (c converterBasedLocalDaysCalculator) Now() time.Time {
+	return c.now().UTC()
+}
+
+// startOfLocalDay returns the first point in time (as UTC) that belongs to the local day of the given date.
+// Out-of-range values of month and day are normalized like in time.Date.
+// Usually this is simply local midnight (or the configured day start). But in time zones that switch to daylight saving time at midnight (e.g. "America/Santiago"), local midnight does not exist on that day and the local day starts at the moment of the transition (01:00am local time).
+// And if the clocks are set back from 01:00am to midnight (e.g. "Atlantic/Azores"), local midnight occurs twice and the local day starts at the first one.
+func (c converterBasedLocalDaysCalculator) startOfLocalDay(year int, month time.Month, day int) time.Time {
+	if converter, ok := c.converter.(locationBasedLocalTimeConverter); ok {
+		if start, ok := converter.startOfLocalDay(year, month, day, c.dayStart); ok {
+			return start
+		}
+	}
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	wall := date.Add(c.dayStart)
+	start := c.atLocalWallClock(wall)
+	if c.isStartOfLocalDay(start, date) {
+		return start
+	}
+	// if the start of the day is skipped or ambiguous, the offset is not guaranteed to be the right one, so we try the offsets from before and after the transition
+	candidates := []time.Time{start}
+	for _, reference := range []time.Time{start.Add(-24 * time.Hour), start.Add(24 * time.Hour)} {
+		candidate := atOffset(wall, c.offsetAt(reference))
+		if c.isStartOfLocalDay(candidate, date) {
+			return candidate
+		}
+		candidates = append(candidates, candidate)
+	}
+	// the transition skips the start of the day without starting exactly at it (e.g. a day start at 00:30 when the clocks are set forward from 00:00 to 01:00), so we search for the transition
+	var before, after time.Time
+	for _, candidate := range candidates {
+		if c.localDay(candidate).Before(date) {
+			if before.IsZero() || candidate.After(before) {
+				before = candidate
+			}
+		} else if after.IsZero() || candidate.Before(after) {
+			after = candidate
+		}
+	}
+	if before.IsZero() || after.IsZero() || !before.Before(after) {
+		return start
+	}
+	for after.Sub(before) > time.Nanosecond {
+		middle := before.Add(after.Sub(before) / 2)
+		if c.localDay(middle).Before(date) {
+			before = middle
+		} else {
+			after = middle
+		}
+	}
+	return after
+}
+
+// startOfLocalDayAfter returns the first start of the local day of the given date (see startOfLocalDay) that is after timestamp.
+// The two differ only if the clocks are set back across the start of the day and timestamp lies in the repeated part of the previous day (e.g. "Antarctica/Casey" on 2010-03-05): then the local day starts a second time, after timestamp.
+func (c converterBasedLocalDaysCalculator) startOfLocalDayAfter(timestamp time.Time, year int, month time.Month, day int) time.Time {
+	start := c.startOfLocalDay(year, month, day)
+	if start.After(timestamp) {
+		return start
+	}
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	candidate := atOffset(date.Add(c.dayStart), c.offsetAt(timestamp))
+	if candidate.After(timestamp) && c.isStartOfLocalDay(candidate, date) {
+		return candidate
+	}
+	// the offset at timestamp does not lead to the next start of the day, so we step forward to a point in time that belongs to the day and search for the transition
+	before, after := timestamp, timestamp.Add(time.Hour)
+	for c.localDay(after).Before(date) {
+		before, after = after, after.Add(time.Hour)
+	}
+	for after.Sub(before) > time.Nanosecond {
+		middle := before.Add(after.Sub(before) / 2)
+		if c.localDay(middle).Before(date) {
+			before = middle
+		} else {
+			after = middle
+		}
+	}
+	return after
+}
+
+// isStartOfLocalDay returns true if the local day of timestamp is the one of the given date (or later) but the local day one nanosecond earlier is not.
+func (c converterBasedLocalDaysCalculator) isStartOfLocalDay(timestamp time.Time, date time.Time) bool {
+	return !c.localDay(timestamp).Before(date) && c.localDay(timestamp.Add(-time.Nanosecond)).Before(date)
+}
+
+// fromLocalWallClock returns the point in time (as UTC) at which the local wall clock shows the given wallClock (see wallClock).
+// If the wall clock time occurs twice (because the clocks are set back), the one with the preferredOffset (in seconds east of UTC) is returned, if possible.
+// If the wall clock time does not exist (because the clocks are set forward), the returned point in time is shifted forward by the length of the gap.
+func (c converterBasedLocalDaysCalculator) fromLocalWallClock(wall time.Time, preferredOffset int) time.Time {
+	candidate := atOffset(wall, preferredOffset)
+	if wallClock(c.toLocalTime(candidate)).Equal(wall) {
+		return candidate
+	}
+	candidate = c.atLocalWallClock(wall)
+	if wallClock(c.toLocalTime(candidate)).Equal(wall) {
+		return candidate
+	}
+	// the wall clock time is skipped. Using the offset from before the gap results in a point in time after the gap.
+	return atOffset(wall, c.offsetAt(candidate.Add(-24*time.Hour)))
+}
+
+// atLocalWallClock returns a point in time (as UTC) at which the local wall clock shows approximately the given wallClock. Like time.Date, it guesses the offset at wall, then corrects it using the offset at the guess.
+// The result is exact unless the wall clock time is skipped or ambiguous, because the local time changes its offset.
+func (c converterBasedLocalDaysCalculator) atLocalWallClock(wall time.Time) time.Time {
+	guess := atOffset(wall, c.offsetAt(wall))
+	return atOffset(wall, c.offsetAt(guess))
+}
+
+// offsetAt returns the offset (in seconds east of UTC) of the local time at timestamp.
+func (c converterBasedLocalDaysCalculator) offsetAt(timestamp time.Time) int {
+	_, offset := c.toLocalTime(timestamp).Zone()
+	return offset
+}
+
+// atOffset returns the point in time (as UTC) at which a clock with the given offset (in seconds east of UTC) shows the given wallClock.
+func atOffset(wall time.Time, offset int) time.Time {
+	return wall.Add(-time.Duration(offset) * time.Second)
+}
+
+// wallClock returns date and clock time of localTime as if it was UTC, so that wall clock times can be compared and calculated with regardless of UTC offsets.
+func wallClock(localTime time.Time) time.Time {
+	year, month, day := localTime.Date()
+	hour, minute, second := localTime.Clock()
+	return time.Date(year, month, day, hour, minute, second, localTime.Nanosecond(), time.UTC)
+}
+
+// localDay returns the date of the local day timestamp belongs to, as midnight UTC, so that dates can be compared regardless of UTC offsets.
+// Without a day start (see WithDayStart) this is simply the local date of timestamp.
+func (c converterBasedLocalDaysCalculator) localDay(timestamp time.Time) time.Time {
+	year, month, day := c.localDate(timestamp)
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// localDate is like localDay but returns year, month and day, which is cheaper if they are only used to compute other dates.
+func (c converterBasedLocalDaysCalculator) localDate(timestamp time.Time) (year int, month time.Month, day int) {
+	localTime := c.toLocalTime(timestamp)
+	if c.dayStart != 0 {
+		localTime = wallClock(localTime).Add(-c.dayStart)
+	}
+	return localTime.Date()
+}
+
+// Location returns the location whose local time calculator uses. It returns nil if calculator does not implement LocationProvider, e.g. because it's based on a ToLocalTimeConverter that does not implement LocationProvider.
+func Location(calculator LocalDaysCalculator) *time.Location {
+	if provider, ok := calculator.(LocationProvider); ok {
+		return provider.Location()
+	}
+	return nil
+}
+
+// ZoneName returns the name of the location of calculator (e.g. "Europe/Berlin", see Location). It returns an empty string if calculator does not implement LocationProvider or its location is nil.
+func ZoneName(calculator LocalDaysCalculator) string {
+	if provider, ok := calculator.(LocationProvider); ok {
+		return provider.ZoneName()
+	}
+	return ""
+}
+
+// Location returns the location of the converter or nil if the converter does not implement LocationProvider.
+func (c converterBasedLocalDaysCalculator) Location() *time.Location {
+	if provider, ok := c.converter.(LocationProvider); ok {
+		return provider.Location()
+	}
+	return nil
+}
+
+// ZoneName returns the zone name of the converter or an empty string if the converter does not implement LocationProvider.
+func (c converterBasedLocalDaysCalculator) ZoneName() string {
+	if provider, ok := c.converter.(LocationProvider); ok {
+		return provider.ZoneName()
+	}
+	return ""
 }
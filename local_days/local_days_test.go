@@ -0,0 +1,1062 @@
+package local_days_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/corbym/gocrest/is"
+	"github.com/corbym/gocrest/then"
+	"github.com/hochfrequenz/go-local-days/conformance"
+	"github.com/hochfrequenz/go-local-days/embedded_tzdata"
+	"github.com/hochfrequenz/go-local-days/local_days"
+	"github.com/stretchr/testify/suite"
+)
+
+/*****************************
+ Location based Constructors
+*****************************/
+
+// Test_Location_Based_Converter tests that a calculator can be created from an already loaded location.
+func (s *Suite) Test_Location_Based_Converter() {
+	location, err := time.LoadLocation("Europe/Berlin")
+	then.AssertThat(s.T(), err, is.Nil())
+	berlin := local_days.NewLocationBasedLocalTimeConverter(location)
+	actual := berlin.StartOfLocalDay(time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), actual, is.EqualTo(time.Date(2022, 5, 31, 22, 0, 0, 0, time.UTC)))
+}
+
+// Test_Location_Based_Converter_Nil tests that there is no calculator without a location.
+func (s *Suite) Test_Location_Based_Converter_Nil() {
+	s.Panics(func() { local_days.NewLocationBasedLocalTimeConverter(nil) })
+}
+
+// Test_TZData_Based_Converter tests that a calculator can be created from TZif data.
+func (s *Suite) Test_TZData_Based_Converter() {
+	berlin, err := local_days.NewTZDataBasedLocalTimeConverter("Europe/Berlin", s.readTZData("Europe/Berlin"))
+	then.AssertThat(s.T(), err, is.Nil())
+	actual := berlin.AddLocalDays(time.Date(2022, 10, 30, 0, 0, 0, 0, time.UTC), 1)
+	then.AssertThat(s.T(), actual, is.EqualTo(time.Date(2022, 10, 31, 1, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), conformance.Check(berlin, 2020, 2025), is.Empty())
+}
+
+// Test_TZData_Based_Converter_Invalid_Data tests that invalid TZif data is reported as error.
+func (s *Suite) Test_TZData_Based_Converter_Invalid_Data() {
+	_, err := local_days.NewTZDataBasedLocalTimeConverter("Europe/Berlin", []byte("foo"))
+	then.AssertThat(s.T(), err, is.Not(is.Nil()))
+}
+
+// Test_Fixed_Offset_Converter tests calculators with fixed offsets east and west of UTC.
+func (s *Suite) Test_Fixed_Offset_Converter() {
+	for offset, expectedStartOfDay := range map[time.Duration]time.Time{
+		time.Hour:                     time.Date(2022, 5, 31, 23, 0, 0, 0, time.UTC),
+		-5*time.Hour - 30*time.Minute: time.Date(2022, 6, 1, 5, 30, 0, 0, time.UTC),
+		0:                             time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		calculator := local_days.NewFixedOffsetLocalTimeConverter(offset)
+		then.AssertThat(s.T(), calculator.StartOfLocalDay(time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)), is.EqualTo(expectedStartOfDay))
+		then.AssertThat(s.T(), conformance.Check(calculator, 2022, 2022), is.Empty())
+	}
+	s.Panics(func() { local_days.NewFixedOffsetLocalTimeConverter(25 * time.Hour) })
+	s.Panics(func() { local_days.NewFixedOffsetLocalTimeConverter(time.Millisecond) })
+}
+
+// Test_TZData_Version_Unknown tests that the tzdata version is unknown if the calculator is not based on tzdata.
+func (s *Suite) Test_TZData_Version_Unknown() {
+	then.AssertThat(s.T(), local_days.TZDataVersion(local_days.NewFixedOffsetLocalTimeConverter(time.Hour)), is.EqualTo(""))
+}
+
+// expectedTZDataVersionVariable is set if Test_TZData_Version_From_Zoneinfo_Directory runs in its own process.
+const expectedTZDataVersionVariable = "LOCAL_DAYS_TEST_EXPECTED_TZDATA_VERSION"
+
+// Test_TZData_Version_From_Zoneinfo_Directory tests that the tzdata version is read from the directory the zones are loaded from.
+// Like the time package, the calculators read ZONEINFO only once, so the test runs itself in a new process in which ZONEINFO is set from the start.
+func (s *Suite) Test_TZData_Version_From_Zoneinfo_Directory() {
+	if expectedVersion, ok := os.LookupEnv(expectedTZDataVersionVariable); ok {
+		berlin := local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin")
+		then.AssertThat(s.T(), local_days.TZDataVersion(berlin), is.EqualTo(expectedVersion))
+		return
+	}
+	directory := s.T().TempDir()
+	then.AssertThat(s.T(), os.MkdirAll(filepath.Join(directory, "Europe"), 0o755), is.Nil())
+	then.AssertThat(s.T(), ioutil.WriteFile(filepath.Join(directory, "Europe", "Berlin"), s.readTZData("Europe/Berlin"), 0o644), is.Nil())
+	then.AssertThat(s.T(), ioutil.WriteFile(filepath.Join(directory, "tzdata.zi"), []byte("# version 2042z\n# Rule..."), 0o644), is.Nil())
+	test := exec.Command(os.Args[0], "-test.run=^TestInit$/^Test_TZData_Version_From_Zoneinfo_Directory$")
+	test.Env = append(os.Environ(), "ZONEINFO="+directory, expectedTZDataVersionVariable+"=2042z")
+	output, err := test.CombinedOutput()
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), string(output), is.ValueContaining("PASS"))
+}
+
+/****************************
+ POSIX TZ based Constructor
+****************************/
+
+// Test_POSIX_TZ_Based_Converter_With_DST tests that the rules from a POSIX TZ string result in the same local days as the tzdata for Germany.
+func (s *Suite) Test_POSIX_TZ_Based_Converter_With_DST() {
+	posixBerlin, err := local_days.NewPOSIXTZBasedLocalTimeConverter("CET-1CEST,M3.5.0,M10.5.0/3")
+	then.AssertThat(s.T(), err, is.Nil())
+	berlin := local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin")
+	for timestamp := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC); timestamp.Year() < 2040; timestamp = timestamp.Add(5 * time.Hour) {
+		then.AssertThat(s.T(), posixBerlin.StartOfLocalDay(timestamp), is.EqualTo(berlin.StartOfLocalDay(timestamp)))
+	}
+	then.AssertThat(s.T(), conformance.Check(posixBerlin, 1990, 2040), is.Empty())
+}
+
+// Test_POSIX_TZ_Based_Converter_Without_DST tests a scenario in which Germany abolished daylight saving time.
+func (s *Suite) Test_POSIX_TZ_Based_Converter_Without_DST() {
+	alwaysCET, err := local_days.NewPOSIXTZBasedLocalTimeConverter("CET-1")
+	then.AssertThat(s.T(), err, is.Nil())
+	actual := alwaysCET.StartOfLocalDay(time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), actual, is.EqualTo(time.Date(2022, 5, 31, 23, 0, 0, 0, time.UTC)))
+}
+
+// Test_POSIX_TZ_Based_Converter_Southern_Hemisphere tests rules with quoted names, a negative offset and DST at midnight, like in Chile.
+func (s *Suite) Test_POSIX_TZ_Based_Converter_Southern_Hemisphere() {
+	chile, err := local_days.NewPOSIXTZBasedLocalTimeConverter("<-04>4<-03>,M9.1.6/24,M4.1.6/24")
+	then.AssertThat(s.T(), err, is.Nil())
+	// DST starts on Sunday, 2022-09-04 at 00:00 local time, so the local day starts at 01:00 local time
+	actual := chile.StartOfLocalDay(time.Date(2022, 9, 4, 12, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), actual, is.EqualTo(time.Date(2022, 9, 4, 4, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), conformance.Check(chile, 2020, 2025), is.Empty())
+}
+
+// Test_POSIX_TZ_Based_Converter_Invalid tests that invalid POSIX TZ strings are reported as error.
+func (s *Suite) Test_POSIX_TZ_Based_Converter_Invalid() {
+	for _, tz := range []string{
+		"",
+		"CE-1",
+		"CET",
+		"CET-25",
+		"CET-1CEST",
+		"CET-1CEST,M3.5.0",
+		"CET-1CEST,M13.5.0,M10.5.0/3",
+		"CET-1CEST,M3.5.0,M10.5.0/3foo",
+		"<+3>-3",
+		"Europe/Berlin",
+	} {
+		_, err := local_days.NewPOSIXTZBasedLocalTimeConverter(tz)
+		then.AssertThat(s.T(), err, is.Not(is.Nil()))
+	}
+}
+
+/******************************
+ Converter based Calculators
+******************************/
+
+// simpleSummerTimeConverter is a legacy time model in which the local time is UTC+2 from April to September and UTC+1 otherwise (based on the month in UTC).
+type simpleSummerTimeConverter struct{}
+
+func (simpleSummerTimeConverter) ToLocalTime(timestamp time.Time) time.Time {
+	utc := timestamp.UTC()
+	if utc.Month() >= time.April && utc.Month() <= time.September {
+		return utc.In(time.FixedZone("summer", 2*60*60))
+	}
+	return utc.In(time.FixedZone("winter", 60*60))
+}
+
+// Test_Converter_Based_Calculator tests that custom converters get all the day and month logic.
+func (s *Suite) Test_Converter_Based_Calculator() {
+	calculator := local_days.NewConverterBasedLocalDaysCalculator(simpleSummerTimeConverter{})
+	then.AssertThat(s.T(), calculator.StartOfLocalDay(time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2022, 5, 31, 22, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), calculator.StartOfNextLocalMonth(time.Date(2022, 3, 15, 0, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2022, 3, 31, 23, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), calculator.AddLocalDays(time.Date(2022, 9, 30, 12, 0, 0, 0, time.UTC), 1), is.EqualTo(time.Date(2022, 10, 1, 13, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), conformance.Check(calculator, 2021, 2022), is.Empty())
+	then.AssertThat(s.T(), local_days.Location(calculator), is.Nil())
+	then.AssertThat(s.T(), local_days.ZoneName(calculator), is.EqualTo(""))
+}
+
+// Test_Converter_Based_Calculator_Nil tests that there is no calculator without a converter.
+func (s *Suite) Test_Converter_Based_Calculator_Nil() {
+	s.Panics(func() { local_days.NewConverterBasedLocalDaysCalculator(nil) })
+}
+
+// Test_Location_And_Zone_Name tests that the calculators expose their location.
+func (s *Suite) Test_Location_And_Zone_Name() {
+	berlin := local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin")
+	then.AssertThat(s.T(), local_days.ZoneName(berlin), is.EqualTo("Europe/Berlin"))
+	then.AssertThat(s.T(), local_days.Location(berlin).String(), is.EqualTo("Europe/Berlin"))
+	then.AssertThat(s.T(), local_days.ZoneName(local_days.NewFixedOffsetLocalTimeConverter(-90*time.Minute)), is.EqualTo("UTC-01:30"))
+	posixBerlin, err := local_days.NewPOSIXTZBasedLocalTimeConverter("CET-1CEST,M3.5.0,M10.5.0/3")
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), local_days.ZoneName(posixBerlin), is.EqualTo("CET-1CEST,M3.5.0,M10.5.0/3"))
+	provider, ok := berlin.(local_days.LocationProvider)
+	then.AssertThat(s.T(), ok, is.True())
+	then.AssertThat(s.T(), provider.ZoneName(), is.EqualTo("Europe/Berlin"))
+	instrumented := local_days.NewInstrumentedLocalDaysCalculator(berlin, local_days.CallHookFunc(func(local_days.Call) {})).(local_days.LocationProvider)
+	then.AssertThat(s.T(), instrumented.ZoneName(), is.EqualTo("Europe/Berlin"))
+}
+
+/**********
+ Options
+**********/
+
+// Test_Day_Start tests local days that start at 06:00 local time (like the gas day) across DST transitions.
+func (s *Suite) Test_Day_Start() {
+	gasDays := local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin", local_days.WithDayStart(6*time.Hour))
+	// 2022-03-27 05:00 CEST is still on the local day that started on 2022-03-26 06:00 CET
+	then.AssertThat(s.T(), gasDays.StartOfLocalDay(time.Date(2022, 3, 27, 3, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2022, 3, 26, 5, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), gasDays.StartOfNextLocalDay(time.Date(2022, 3, 27, 3, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2022, 3, 27, 4, 0, 0, 0, time.UTC)))
+	// 2022-10-01 05:00 CEST is still in September
+	then.AssertThat(s.T(), gasDays.StartOfLocalMonth(time.Date(2022, 10, 1, 3, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2022, 9, 1, 4, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), gasDays.StartOfNextLocalMonth(time.Date(2022, 10, 1, 3, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2022, 10, 1, 4, 0, 0, 0, time.UTC)))
+	// Monday, 2022-11-14 05:00 CET is still on Sunday's local day
+	then.AssertThat(s.T(), gasDays.GetLocalWeekday(time.Date(2022, 11, 14, 4, 0, 0, 0, time.UTC)), is.EqualTo(time.Sunday))
+	then.AssertThat(s.T(), gasDays.IsLocalMidnight(time.Date(2022, 11, 14, 5, 0, 0, 0, time.UTC)), is.True())
+	then.AssertThat(s.T(), conformance.Check(gasDays, 2022, 2022), is.Empty())
+}
+
+// Test_Day_Start_Skipped tests a day start that is skipped on the day on which DST starts.
+func (s *Suite) Test_Day_Start_Skipped() {
+	santiago := local_days.NewTimeZoneBasedLocalTimeConverter("America/Santiago", local_days.WithDayStart(30*time.Minute))
+	// 2022-09-11 00:30 does not exist in Santiago because the clocks are set forward from 00:00 to 01:00
+	then.AssertThat(s.T(), santiago.StartOfLocalDay(time.Date(2022, 9, 11, 12, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2022, 9, 11, 4, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), conformance.Check(santiago, 2021, 2023), is.Empty())
+}
+
+// Test_Midnight_Repeated tests that the local day starts at the first midnight if the clocks are set back across midnight.
+func (s *Suite) Test_Midnight_Repeated() {
+	casey := local_days.NewTimeZoneBasedLocalTimeConverter("Antarctica/Casey")
+	// on 2010-03-05 the clocks were set back from 02:00 (UTC+11) to 23:00 (UTC+08) of the previous day
+	then.AssertThat(s.T(), casey.StartOfLocalDay(time.Date(2010, 3, 4, 14, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2010, 3, 4, 13, 0, 0, 0, time.UTC)))
+	// from 15:00 to 16:00 UTC the local date is 2010-03-04 again, so the next local day starts at the second local midnight
+	repeated := time.Date(2010, 3, 4, 15, 30, 0, 0, time.UTC)
+	then.AssertThat(s.T(), casey.StartOfNextLocalDay(repeated), is.EqualTo(time.Date(2010, 3, 4, 16, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), casey.NextLocalWeekday(repeated, time.Friday), is.EqualTo(time.Date(2010, 3, 4, 16, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), casey.IsLocalMidnight(time.Date(2010, 3, 4, 13, 0, 0, 0, time.UTC)), is.True())
+	then.AssertThat(s.T(), casey.IsLocalMidnight(time.Date(2010, 3, 4, 16, 0, 0, 0, time.UTC)), is.True())
+}
+
+// Test_Week_Start tests local weeks that start on Monday (default) and Sunday.
+func (s *Suite) Test_Week_Start() {
+	wednesday := time.Date(2022, 11, 16, 12, 0, 0, 0, time.UTC)
+	berlin := local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin").(local_days.LocalWeeksCalculator)
+	then.AssertThat(s.T(), berlin.StartOfLocalWeek(wednesday), is.EqualTo(time.Date(2022, 11, 13, 23, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), berlin.StartOfNextLocalWeek(wednesday), is.EqualTo(time.Date(2022, 11, 20, 23, 0, 0, 0, time.UTC)))
+	sundays := local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin", local_days.WithWeekStart(time.Sunday)).(local_days.LocalWeeksCalculator)
+	then.AssertThat(s.T(), sundays.StartOfLocalWeek(wednesday), is.EqualTo(time.Date(2022, 11, 12, 23, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), sundays.StartOfNextLocalWeek(wednesday), is.EqualTo(time.Date(2022, 11, 19, 23, 0, 0, 0, time.UTC)))
+	// the week of the switch to CEST is one hour shorter
+	then.AssertThat(s.T(), berlin.StartOfNextLocalWeek(time.Date(2022, 3, 22, 0, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2022, 3, 27, 22, 0, 0, 0, time.UTC)))
+}
+
+// Test_Strict_Input_Validation tests that non-UTC timestamps are rejected in strict mode only.
+func (s *Suite) Test_Strict_Input_Validation() {
+	berlinTime := time.Date(2022, 11, 16, 12, 0, 0, 0, time.FixedZone("CET", 60*60))
+	strict := local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin", local_days.WithStrictInputValidation())
+	s.Panics(func() { strict.StartOfLocalDay(berlinTime) })
+	s.NotPanics(func() { strict.StartOfLocalDay(berlinTime.UTC()) })
+	s.Panics(func() { strict.IsLocalMidnight(time.Now()) })
+	s.NotPanics(func() { strict.IsLocalMidnight(local_days.NormalizeTimestamp(time.Now())) })
+	lenient := local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin")
+	s.NotPanics(func() { lenient.StartOfLocalDay(berlinTime) })
+}
+
+// Test_Validate_Timestamp tests the convention that timestamps are in UTC and without monotonic clock reading.
+func (s *Suite) Test_Validate_Timestamp() {
+	then.AssertThat(s.T(), local_days.ValidateTimestamp(time.Date(2022, 11, 16, 12, 0, 0, 0, time.UTC)), is.Nil())
+	then.AssertThat(s.T(), local_days.ValidateTimestamp(time.Date(2022, 11, 16, 12, 0, 0, 0, time.FixedZone("UTC", 0))), is.Not(is.Nil()))
+	withMonotonicClock := time.Now()
+	then.AssertThat(s.T(), local_days.ValidateTimestamp(withMonotonicClock), is.Not(is.Nil()))
+	then.AssertThat(s.T(), local_days.ValidateTimestamp(withMonotonicClock.Round(0)), is.Not(is.Nil())) // still in time.Local
+	then.AssertThat(s.T(), local_days.ValidateTimestamp(local_days.NormalizeTimestamp(withMonotonicClock)), is.Nil())
+	then.AssertThat(s.T(), local_days.NormalizeTimestamp(withMonotonicClock).Equal(withMonotonicClock), is.True())
+}
+
+// Test_Is_Local_Midnight_Ignores_Location tests that IsLocalMidnight compares points in time, not their representation.
+func (s *Suite) Test_Is_Local_Midnight_Ignores_Location() {
+	berlin := local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin")
+	berlinMidnight := time.Date(2022, 11, 16, 0, 0, 0, 0, time.FixedZone("CET", 60*60))
+	then.AssertThat(s.T(), berlin.IsLocalMidnight(berlinMidnight), is.True())
+	then.AssertThat(s.T(), berlin.IsLocalMidnight(berlinMidnight.UTC()), is.True())
+	then.AssertThat(s.T(), berlin.StartOfLocalDay(berlinMidnight.Add(time.Hour)), is.EqualTo(berlinMidnight.UTC()))
+}
+
+// Test_Holiday_Calendar tests holidays and business days.
+func (s *Suite) Test_Holiday_Calendar() {
+	christmas := local_days.HolidayCalendarFunc(func(year int, month time.Month, day int) bool {
+		return month == time.December && (day == 25 || day == 26)
+	})
+	berlin := local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin", local_days.WithHolidayCalendar(christmas)).(local_days.BusinessDaysCalculator)
+	then.AssertThat(s.T(), berlin.IsLocalHoliday(time.Date(2022, 12, 24, 22, 59, 0, 0, time.UTC)), is.False())
+	then.AssertThat(s.T(), berlin.IsLocalHoliday(time.Date(2022, 12, 24, 23, 0, 0, 0, time.UTC)), is.True())
+	then.AssertThat(s.T(), berlin.IsLocalBusinessDay(time.Date(2022, 12, 26, 12, 0, 0, 0, time.UTC)), is.False()) // Monday, but a holiday
+	then.AssertThat(s.T(), berlin.IsLocalBusinessDay(time.Date(2022, 12, 27, 12, 0, 0, 0, time.UTC)), is.True())
+	then.AssertThat(s.T(), berlin.IsLocalBusinessDay(time.Date(2022, 12, 31, 12, 0, 0, 0, time.UTC)), is.False()) // Saturday
+	withoutHolidays := local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin").(local_days.BusinessDaysCalculator)
+	then.AssertThat(s.T(), withoutHolidays.IsLocalBusinessDay(time.Date(2022, 12, 26, 12, 0, 0, 0, time.UTC)), is.True())
+}
+
+// Test_Clock tests that the calculator uses the configured clock.
+func (s *Suite) Test_Clock() {
+	now := time.Date(2022, 11, 16, 13, 0, 0, 0, time.FixedZone("CET", 60*60))
+	berlin := local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin", local_days.WithClock(func() time.Time { return now }))
+	clock := berlin.(local_days.Clock)
+	then.AssertThat(s.T(), clock.Now(), is.EqualTo(time.Date(2022, 11, 16, 12, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), berlin.StartOfLocalDay(clock.Now()), is.EqualTo(time.Date(2022, 11, 15, 23, 0, 0, 0, time.UTC)))
+}
+
+// Test_Invalid_Options tests that invalid options are rejected.
+func (s *Suite) Test_Invalid_Options() {
+	s.Panics(func() { local_days.WithDayStart(24 * time.Hour) })
+	s.Panics(func() { local_days.WithDayStart(-time.Hour) })
+	s.Panics(func() { local_days.WithWeekStart(time.Weekday(7)) })
+	s.Panics(func() { local_days.WithClock(nil) })
+}
+
+/*************************
+ Calculator Specifications
+*************************/
+
+// Test_Spec_Build tests that a calculator can be built from a JSON spec.
+func (s *Suite) Test_Spec_Build() {
+	err := local_days.RegisterHolidayRegion("XMAS", local_days.HolidayCalendarFunc(func(year int, month time.Month, day int) bool {
+		return month == time.December && day == 25
+	}))
+	then.AssertThat(s.T(), err, is.Nil())
+	var spec local_days.CalculatorSpec
+	err = json.Unmarshal([]byte(`{"zoneName": "Europe/Berlin", "dayStart": "06:00", "weekStart": "sunday", "holidayRegion": "XMAS"}`), &spec)
+	then.AssertThat(s.T(), err, is.Nil())
+	gasDays, err := spec.Build()
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), gasDays.StartOfLocalDay(time.Date(2022, 11, 16, 12, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2022, 11, 16, 5, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), gasDays.(local_days.LocalWeeksCalculator).StartOfLocalWeek(time.Date(2022, 11, 16, 12, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2022, 11, 13, 5, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), gasDays.(local_days.BusinessDaysCalculator).IsLocalHoliday(time.Date(2022, 12, 25, 12, 0, 0, 0, time.UTC)), is.True())
+	fixed, err := local_days.CalculatorSpec{FixedOffset: "-03:30"}.Build()
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), local_days.ZoneName(fixed), is.EqualTo("UTC-03:30"))
+}
+
+// Test_Spec_Round_Trip tests that the spec of a calculator builds an equivalent calculator and survives JSON serialization.
+func (s *Suite) Test_Spec_Round_Trip() {
+	for _, calculator := range []local_days.LocalDaysCalculator{
+		local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin"),
+		local_days.NewTimeZoneBasedLocalTimeConverter("America/Santiago", local_days.WithDayStart(30*time.Minute+15*time.Second), local_days.WithWeekStart(time.Saturday)),
+		local_days.NewFixedOffsetLocalTimeConverter(time.Hour, local_days.WithStrictInputValidation()),
+		local_days.NewFixedOffsetLocalTimeConverter(-5*time.Hour - 30*time.Minute),
+	} {
+		spec, err := local_days.SpecOf(calculator)
+		then.AssertThat(s.T(), err, is.Nil())
+		serialized, err := json.Marshal(spec)
+		then.AssertThat(s.T(), err, is.Nil())
+		var deserialized local_days.CalculatorSpec
+		then.AssertThat(s.T(), json.Unmarshal(serialized, &deserialized), is.Nil())
+		then.AssertThat(s.T(), deserialized, is.EqualTo(spec))
+		rebuilt, err := deserialized.Build()
+		then.AssertThat(s.T(), err, is.Nil())
+		then.AssertThat(s.T(), local_days.CompareCalculators(calculator, rebuilt, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)).IsEmpty(), is.True())
+		rebuiltSpec, err := local_days.SpecOf(rebuilt)
+		then.AssertThat(s.T(), err, is.Nil())
+		then.AssertThat(s.T(), rebuiltSpec, is.EqualTo(spec))
+	}
+	spec, _ := local_days.SpecOf(local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin", local_days.WithDayStart(6*time.Hour)))
+	then.AssertThat(s.T(), spec, is.EqualTo(local_days.CalculatorSpec{ZoneName: "Europe/Berlin", DayStart: "06:00"}))
+}
+
+// Test_Spec_Not_Describable tests that calculators which cannot be described by a spec are reported.
+func (s *Suite) Test_Spec_Not_Describable() {
+	posix, err := local_days.NewPOSIXTZBasedLocalTimeConverter("CET-1")
+	then.AssertThat(s.T(), err, is.Nil())
+	_, err = local_days.SpecOf(posix)
+	then.AssertThat(s.T(), err, is.Not(is.Nil()))
+	unregisteredHolidays := local_days.HolidayCalendarFunc(func(int, time.Month, int) bool { return false })
+	_, err = local_days.SpecOf(local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin", local_days.WithHolidayCalendar(unregisteredHolidays)))
+	then.AssertThat(s.T(), err, is.Not(is.Nil()))
+}
+
+// Test_Spec_Validation tests that validation errors point at the invalid field.
+func (s *Suite) Test_Spec_Validation() {
+	for spec, field := range map[local_days.CalculatorSpec]string{
+		{}:                            "zoneName",
+		{ZoneName: "Europe/Atlantis"}: "zoneName",
+		{ZoneName: "Europe/Berlin", FixedOffset: "+01:00"}:     "fixedOffset",
+		{FixedOffset: "+24:00"}:                                "fixedOffset",
+		{FixedOffset: "one hour"}:                              "fixedOffset",
+		{ZoneName: "Europe/Berlin", DayStart: "-06:00"}:        "dayStart",
+		{ZoneName: "Europe/Berlin", DayStart: "06:60"}:         "dayStart",
+		{ZoneName: "Europe/Berlin", DayStart: "06:00 CET"}:     "dayStart",
+		{ZoneName: "Europe/Berlin", WeekStart: "Montag"}:       "weekStart",
+		{ZoneName: "Europe/Berlin", HolidayRegion: "Atlantis"}: "holidayRegion",
+	} {
+		err := spec.Validate()
+		var fieldError *local_days.SpecFieldError
+		then.AssertThat(s.T(), errors.As(err, &fieldError), is.True())
+		then.AssertThat(s.T(), fieldError.Field, is.EqualTo(field))
+	}
+	then.AssertThat(s.T(), local_days.CalculatorSpec{ZoneName: "Europe/Berlin", DayStart: "23:59:59"}.Validate(), is.Nil())
+	then.AssertThat(s.T(), local_days.RegisterHolidayRegion("", local_days.HolidayCalendarFunc(nil)), is.Not(is.Nil()))
+	then.AssertThat(s.T(), local_days.RegisterHolidayRegion("NIL", nil), is.Not(is.Nil()))
+}
+
+/**********
+ Registry
+**********/
+
+// Test_Registry_Caches_Calculators tests that the registry returns the same instance (and hence the same location) for the same zone.
+func (s *Suite) Test_Registry_Caches_Calculators() {
+	registry := local_days.NewRegistry()
+	first, err := registry.Get("Europe/Berlin")
+	then.AssertThat(s.T(), err, is.Nil())
+	second, err := registry.Get("Europe/Berlin")
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), local_days.Location(second) == local_days.Location(first), is.True())
+	gasDays, err := registry.GetSpec(local_days.CalculatorSpec{ZoneName: "Europe/Berlin", DayStart: "06:00"})
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), local_days.Location(gasDays) == local_days.Location(first), is.False())
+	then.AssertThat(s.T(), gasDays.StartOfLocalDay(time.Date(2022, 11, 16, 12, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2022, 11, 16, 5, 0, 0, 0, time.UTC)))
+	_, err = registry.Get("Europe/Atlantis")
+	then.AssertThat(s.T(), err, is.Not(is.Nil()))
+}
+
+// Test_Registry_Zero_Value tests that the zero value of Registry can be used without NewRegistry.
+func (s *Suite) Test_Registry_Zero_Value() {
+	var registry local_days.Registry
+	first, err := registry.Get("Europe/Berlin")
+	then.AssertThat(s.T(), err, is.Nil())
+	second, err := registry.Get("Europe/Berlin")
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), local_days.Location(second) == local_days.Location(first), is.True())
+}
+
+// Test_Registry_Concurrent_Access tests that concurrent calls of Get return the same instance.
+func (s *Suite) Test_Registry_Concurrent_Access() {
+	registry := local_days.NewRegistry()
+	calculators := make(chan local_days.LocalDaysCalculator, 100)
+	var wg sync.WaitGroup
+	for i := 0; i < cap(calculators); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			calculator, _ := registry.Get("Europe/Berlin")
+			calculators <- calculator
+		}()
+	}
+	wg.Wait()
+	close(calculators)
+	first := <-calculators
+	for calculator := range calculators {
+		then.AssertThat(s.T(), local_days.Location(calculator) == local_days.Location(first), is.True())
+	}
+}
+
+// BenchmarkNewTimeZoneBasedLocalTimeConverter measures loading the tzdata on every call.
+func BenchmarkNewTimeZoneBasedLocalTimeConverter(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin")
+		}
+	})
+}
+
+// BenchmarkRegistryGet measures getting a cached calculator from the registry.
+func BenchmarkRegistryGet(b *testing.B) {
+	registry := local_days.NewRegistry()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := registry.Get("Europe/Berlin"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+/********************
+ Default Calculator
+********************/
+
+// Test_Default_Not_Configured tests that a missing default calculator is reported as error.
+func (s *Suite) Test_Default_Not_Configured() {
+	defer s.setEnv(local_days.DefaultZoneEnvironmentVariable, "")()
+	local_days.SetDefault(nil)
+	_, err := local_days.StartOfLocalDay(time.Date(2022, 11, 16, 12, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), errors.Is(err, local_days.ErrNoDefault), is.True())
+}
+
+// Test_Default_From_Environment tests that the zone of the default calculator is read from the environment.
+func (s *Suite) Test_Default_From_Environment() {
+	defer s.setEnv(local_days.DefaultZoneEnvironmentVariable, "Europe/Berlin")()
+	local_days.SetDefault(nil)
+	startOfDay, err := local_days.StartOfLocalDay(time.Date(2022, 11, 16, 12, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), startOfDay, is.EqualTo(time.Date(2022, 11, 15, 23, 0, 0, 0, time.UTC)))
+	nextDay, err := local_days.AddLocalDays(startOfDay, 1)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), nextDay, is.EqualTo(time.Date(2022, 11, 16, 23, 0, 0, 0, time.UTC)))
+	isMidnight, err := local_days.IsLocalMidnight(startOfDay)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), isMidnight, is.True())
+	_, err = local_days.NextLocalWeekday(startOfDay, time.Weekday(7))
+	then.AssertThat(s.T(), err, is.Not(is.Nil()))
+}
+
+// Test_Default_Invalid_Environment tests that an invalid zone in the environment is reported as error that names the variable.
+func (s *Suite) Test_Default_Invalid_Environment() {
+	defer s.setEnv(local_days.DefaultZoneEnvironmentVariable, "Europe/Atlantis")()
+	local_days.SetDefault(nil)
+	_, err := local_days.StartOfLocalMonth(time.Date(2022, 11, 16, 12, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), err, is.Not(is.Nil()))
+	then.AssertThat(s.T(), err.Error(), is.ValueContaining(local_days.DefaultZoneEnvironmentVariable))
+}
+
+// Test_Default_Set_Programmatically tests that a default calculator set using SetDefault takes precedence over the environment.
+func (s *Suite) Test_Default_Set_Programmatically() {
+	defer s.setEnv(local_days.DefaultZoneEnvironmentVariable, "Europe/Atlantis")()
+	local_days.SetDefault(local_days.NewFixedOffsetLocalTimeConverter(0))
+	defer local_days.SetDefault(nil)
+	startOfDay, err := local_days.StartOfLocalDay(time.Date(2022, 11, 16, 12, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), startOfDay, is.EqualTo(time.Date(2022, 11, 16, 0, 0, 0, 0, time.UTC)))
+}
+
+/*****************
+ Instrumentation
+*****************/
+
+// Test_Instrumented_Calculator tests that calls are delegated and reported with inputs, outputs and zone.
+func (s *Suite) Test_Instrumented_Calculator() {
+	var calls []local_days.Call
+	berlin := local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin")
+	instrumented := local_days.NewInstrumentedLocalDaysCalculator(berlin, local_days.CallHookFunc(func(call local_days.Call) {
+		calls = append(calls, call)
+	}))
+	timestamp := time.Date(2022, 11, 16, 12, 0, 0, 0, time.UTC)
+	then.AssertThat(s.T(), instrumented.StartOfLocalDay(timestamp), is.EqualTo(berlin.StartOfLocalDay(timestamp)))
+	then.AssertThat(s.T(), instrumented.AddLocalDays(timestamp, 3), is.EqualTo(berlin.AddLocalDays(timestamp, 3)))
+	then.AssertThat(s.T(), instrumented.IsLocalMidnight(timestamp), is.False())
+	then.AssertThat(s.T(), instrumented.GetLocalWeekday(timestamp), is.EqualTo(time.Wednesday))
+	then.AssertThat(s.T(), local_days.ZoneName(instrumented), is.EqualTo("Europe/Berlin"))
+	then.AssertThat(s.T(), len(calls), is.EqualTo(4))
+	then.AssertThat(s.T(), calls[0].Method, is.EqualTo("StartOfLocalDay"))
+	then.AssertThat(s.T(), calls[0].ZoneName, is.EqualTo("Europe/Berlin"))
+	then.AssertThat(s.T(), calls[0].Timestamp, is.EqualTo(timestamp))
+	then.AssertThat(s.T(), calls[0].Result, is.EqualTo(time.Date(2022, 11, 15, 23, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), calls[1].Argument, is.EqualTo(3))
+	then.AssertThat(s.T(), calls[2].Result, is.EqualTo(false))
+	then.AssertThat(s.T(), calls[3].Result, is.EqualTo(time.Wednesday))
+	then.AssertThat(s.T(), calls[1].String(), is.EqualTo("AddLocalDays(2022-11-16T12:00:00Z, 3) = 2022-11-19T12:00:00Z in Europe/Berlin ("+calls[1].Duration.String()+")"))
+	spec, err := local_days.SpecOf(instrumented)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), spec.ZoneName, is.EqualTo("Europe/Berlin"))
+}
+
+// Test_Instrumented_Calculator_Sampling tests that only every n-th call is reported.
+func (s *Suite) Test_Instrumented_Calculator_Sampling() {
+	var methods []string
+	instrumented := local_days.NewInstrumentedLocalDaysCalculator(local_days.NewFixedOffsetLocalTimeConverter(0), local_days.CallHookFunc(func(call local_days.Call) {
+		methods = append(methods, call.Method)
+	}), local_days.WithSampling(3))
+	timestamp := time.Date(2022, 11, 16, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		instrumented.StartOfLocalDay(timestamp)
+		instrumented.StartOfNextLocalDay(timestamp)
+		instrumented.StartOfLocalMonth(timestamp)
+	}
+	then.AssertThat(s.T(), methods, is.EqualTo([]string{"StartOfLocalDay", "StartOfLocalDay", "StartOfLocalDay"}))
+	s.Panics(func() { local_days.WithSampling(0) })
+	s.Panics(func() { local_days.NewInstrumentedLocalDaysCalculator(nil, nil) })
+}
+
+// Test_Log_Call_Hook tests that calls are printed using the log package.
+func (s *Suite) Test_Log_Call_Hook() {
+	var output bytes.Buffer
+	instrumented := local_days.NewInstrumentedLocalDaysCalculator(local_days.NewFixedOffsetLocalTimeConverter(0), local_days.NewLogCallHook(log.New(&output, "", 0)))
+	instrumented.NextLocalWeekday(time.Date(2022, 11, 16, 12, 0, 0, 0, time.UTC), time.Friday)
+	then.AssertThat(s.T(), output.String(), is.ValueContaining("NextLocalWeekday(2022-11-16T12:00:00Z, Friday) = 2022-11-18T00:00:00Z in UTC+00:00"))
+}
+
+/***********************
+ Typed Errors and V2 API
+***********************/
+
+// Test_V2_Invalid_Weekday tests that an invalid weekday results in an error instead of an endless loop.
+func (s *Suite) Test_V2_Invalid_Weekday() {
+	berlin, err := local_days.CalculatorSpec{ZoneName: "Europe/Berlin"}.BuildV2()
+	then.AssertThat(s.T(), err, is.Nil())
+	timestamp := time.Date(2022, 11, 16, 12, 0, 0, 0, time.UTC)
+	_, err = berlin.NextLocalWeekday(timestamp, time.Weekday(7))
+	then.AssertThat(s.T(), errors.Is(err, local_days.ErrInvalidWeekday), is.True())
+	then.AssertThat(s.T(), errors.Is(err, local_days.ErrInvalidArgument), is.True())
+	var argumentError *local_days.InvalidArgumentError
+	then.AssertThat(s.T(), errors.As(err, &argumentError), is.True())
+	then.AssertThat(s.T(), argumentError.Argument, is.EqualTo("weekday"))
+	then.AssertThat(s.T(), argumentError.Value, is.EqualTo(7))
+	nextFriday, err := berlin.NextLocalWeekday(timestamp, time.Friday)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), nextFriday, is.EqualTo(time.Date(2022, 11, 17, 23, 0, 0, 0, time.UTC)))
+	s.Panics(func() { berlin.V1().NextLocalWeekday(timestamp, time.Weekday(-1)) })
+}
+
+// Test_V2_Strict_Input_Validation tests that timestamps rejected by the strict input validation result in errors instead of panics.
+func (s *Suite) Test_V2_Strict_Input_Validation() {
+	strict, err := local_days.CalculatorSpec{ZoneName: "Europe/Berlin", StrictInputValidation: true}.BuildV2()
+	then.AssertThat(s.T(), err, is.Nil())
+	_, err = strict.StartOfLocalDay(time.Date(2022, 11, 16, 12, 0, 0, 0, time.FixedZone("CET", 60*60)))
+	then.AssertThat(s.T(), errors.Is(err, local_days.ErrNotUTC), is.True())
+	var timestampError *local_days.TimestampError
+	then.AssertThat(s.T(), errors.As(err, &timestampError), is.True())
+	_, err = strict.IsLocalMidnight(time.Now())
+	then.AssertThat(s.T(), errors.Is(err, local_days.ErrMonotonicClockReading), is.True())
+	startOfDay, err := strict.StartOfLocalDay(time.Date(2022, 11, 16, 12, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), startOfDay, is.EqualTo(time.Date(2022, 11, 15, 23, 0, 0, 0, time.UTC)))
+}
+
+// Test_V2_Validates_Wrapped_Calculators tests that invalid inputs are rejected before they reach the wrapped calculator, which would log them when panicking.
+func (s *Suite) Test_V2_Validates_Wrapped_Calculators() {
+	var output bytes.Buffer
+	log.SetOutput(&output)
+	defer log.SetOutput(os.Stderr)
+	strict := local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin", local_days.WithStrictInputValidation())
+	precomputed, err := local_days.NewPrecomputedLocalDaysCalculator(strict, 2022, 2022)
+	then.AssertThat(s.T(), err, is.Nil())
+	instrumented := local_days.NewInstrumentedLocalDaysCalculator(precomputed, local_days.CallHookFunc(func(local_days.Call) {}))
+	notUTC := time.Date(2022, 11, 16, 12, 0, 0, 0, time.FixedZone("CET", 60*60))
+	for _, calculator := range []local_days.LocalDaysCalculator{strict, precomputed, instrumented} {
+		v2, err := local_days.NewLocalDaysCalculatorV2(calculator)
+		then.AssertThat(s.T(), err, is.Nil())
+		_, err = v2.StartOfLocalDay(notUTC)
+		then.AssertThat(s.T(), errors.Is(err, local_days.ErrNotUTC), is.True())
+		_, err = v2.StartOfLocalWeek(time.Now())
+		then.AssertThat(s.T(), errors.Is(err, local_days.ErrMonotonicClockReading), is.True())
+		_, err = v2.NextLocalWeekday(time.Date(2022, 11, 16, 12, 0, 0, 0, time.UTC), time.Weekday(7))
+		then.AssertThat(s.T(), errors.Is(err, local_days.ErrInvalidWeekday), is.True())
+	}
+	then.AssertThat(s.T(), output.String(), is.EqualTo(""))
+}
+
+// Test_V2_Recovers_Panics tests that panics of the wrapped calculator are returned as errors.
+func (s *Suite) Test_V2_Recovers_Panics() {
+	panicking, err := local_days.NewLocalDaysCalculatorV2(panickingCalculator{local_days.NewFixedOffsetLocalTimeConverter(0)})
+	then.AssertThat(s.T(), err, is.Nil())
+	_, err = panicking.StartOfLocalDay(time.Date(2022, 11, 16, 12, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), errors.Is(err, local_days.ErrCalculatorPanicked), is.True())
+	var panicError *local_days.PanicError
+	then.AssertThat(s.T(), errors.As(err, &panicError), is.True())
+	then.AssertThat(s.T(), panicError.Method, is.EqualTo("StartOfLocalDay"))
+	then.AssertThat(s.T(), panicError.Value, is.EqualTo("broken"))
+	_, err = local_days.NewLocalDaysCalculatorV2(nil)
+	then.AssertThat(s.T(), errors.Is(err, local_days.ErrNilArgument), is.True())
+}
+
+// Test_V2_Minimal_Calculator tests that calculators which only implement LocalDaysCalculator get local weeks starting on Monday, no holidays and the system clock.
+func (s *Suite) Test_V2_Minimal_Calculator() {
+	berlin := local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin", local_days.WithHolidayCalendar(local_days.HolidayCalendarFunc(func(int, time.Month, int) bool { return true })))
+	var calculator local_days.LocalDaysCalculator = minimalCalculator{berlin}
+	_, ok := calculator.(local_days.BusinessDaysCalculator)
+	then.AssertThat(s.T(), ok, is.False())
+	minimal, err := local_days.NewLocalDaysCalculatorV2(calculator)
+	then.AssertThat(s.T(), err, is.Nil())
+	wednesday := time.Date(2022, 11, 16, 12, 0, 0, 0, time.UTC)
+	startOfWeek, err := minimal.StartOfLocalWeek(wednesday)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), startOfWeek, is.EqualTo(time.Date(2022, 11, 13, 23, 0, 0, 0, time.UTC)))
+	startOfNextWeek, err := minimal.StartOfNextLocalWeek(startOfWeek)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), startOfNextWeek, is.EqualTo(time.Date(2022, 11, 20, 23, 0, 0, 0, time.UTC)))
+	holiday, err := minimal.IsLocalHoliday(wednesday)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), holiday, is.False())
+	businessDay, err := minimal.IsLocalBusinessDay(time.Date(2022, 11, 19, 12, 0, 0, 0, time.UTC)) // Saturday
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), businessDay, is.False())
+	then.AssertThat(s.T(), minimal.Now().Location(), is.EqualTo(time.UTC))
+	instrumented := local_days.NewInstrumentedLocalDaysCalculator(calculator, local_days.CallHookFunc(func(local_days.Call) {}))
+	then.AssertThat(s.T(), instrumented.(local_days.LocalWeeksCalculator).StartOfLocalWeek(wednesday), is.EqualTo(startOfWeek))
+	then.AssertThat(s.T(), instrumented.(local_days.BusinessDaysCalculator).IsLocalBusinessDay(wednesday), is.True())
+}
+
+// Test_Typed_Construction_Errors tests that construction errors can be inspected.
+func (s *Suite) Test_Typed_Construction_Errors() {
+	_, err := local_days.CalculatorSpec{ZoneName: "Europe/Atlantis"}.BuildV2()
+	then.AssertThat(s.T(), errors.Is(err, local_days.ErrInvalidSpec), is.True())
+	then.AssertThat(s.T(), errors.Is(err, local_days.ErrZoneNotFound), is.True())
+	var zoneError *local_days.ZoneNotFoundError
+	then.AssertThat(s.T(), errors.As(err, &zoneError), is.True())
+	then.AssertThat(s.T(), zoneError.ZoneName, is.EqualTo("Europe/Atlantis"))
+	_, err = local_days.Get("Europe/Atlantis")
+	then.AssertThat(s.T(), errors.Is(err, local_days.ErrZoneNotFound), is.True())
+}
+
+// panickingCalculator panics in StartOfLocalDay.
+type panickingCalculator struct {
+	local_days.LocalDaysCalculator
+}
+
+func (panickingCalculator) StartOfLocalDay(time.Time) time.Time {
+	panic("broken")
+}
+
+// minimalCalculator only implements the methods of LocalDaysCalculator, like calculators from other packages.
+type minimalCalculator struct {
+	local_days.LocalDaysCalculator
+}
+
+/*************************
+ Precomputed Calculators
+*************************/
+
+// Test_Precomputed_Calculator_Identical_Results tests that the precomputed calculator returns the same results as the calculator it's based on, including zones with skipped local midnights and dates.
+func (s *Suite) Test_Precomputed_Calculator_Identical_Results() {
+	for _, calculator := range []local_days.LocalDaysCalculator{
+		local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin"),
+		local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin", local_days.WithDayStart(6*time.Hour), local_days.WithWeekStart(time.Sunday)),
+		local_days.NewTimeZoneBasedLocalTimeConverter("America/Santiago"),
+		local_days.NewTimeZoneBasedLocalTimeConverter("Atlantic/Azores"),
+		local_days.NewTimeZoneBasedLocalTimeConverter("Pacific/Apia", local_days.WithWeekStart(time.Saturday)),
+		local_days.NewFixedOffsetLocalTimeConverter(-10 * time.Hour),
+	} {
+		precomputed, err := local_days.NewPrecomputedLocalDaysCalculator(calculator, 2011, 2012)
+		then.AssertThat(s.T(), err, is.Nil())
+		// the range exceeds the precomputed years to test the delegation, too
+		for timestamp := time.Date(2010, 12, 20, 0, 0, 0, 0, time.UTC); timestamp.Before(time.Date(2013, 1, 10, 0, 0, 0, 0, time.UTC)); timestamp = timestamp.Add(time.Hour) {
+			startOfDay := calculator.StartOfLocalDay(timestamp)
+			for _, t := range []time.Time{timestamp, startOfDay, startOfDay.Add(-time.Nanosecond)} {
+				message := local_days.ZoneName(calculator) + " " + t.String()
+				s.Equal(calculator.StartOfLocalDay(t), precomputed.StartOfLocalDay(t), message)
+				s.Equal(calculator.StartOfNextLocalDay(t), precomputed.StartOfNextLocalDay(t), message)
+				s.Equal(calculator.StartOfLocalMonth(t), precomputed.StartOfLocalMonth(t), message)
+				s.Equal(calculator.StartOfNextLocalMonth(t), precomputed.StartOfNextLocalMonth(t), message)
+				s.Equal(calculator.(local_days.LocalWeeksCalculator).StartOfLocalWeek(t), precomputed.(local_days.LocalWeeksCalculator).StartOfLocalWeek(t), message)
+				s.Equal(calculator.(local_days.LocalWeeksCalculator).StartOfNextLocalWeek(t), precomputed.(local_days.LocalWeeksCalculator).StartOfNextLocalWeek(t), message)
+				s.Equal(calculator.GetLocalWeekday(t), precomputed.GetLocalWeekday(t), message)
+				s.Equal(calculator.IsLocalMidnight(t), precomputed.IsLocalMidnight(t), message)
+				s.Equal(calculator.NextLocalWeekday(t, t.Weekday()), precomputed.NextLocalWeekday(t, t.Weekday()), message)
+			}
+		}
+		then.AssertThat(s.T(), conformance.Check(precomputed, 2010, 2013), is.Empty())
+	}
+}
+
+// Test_Precomputed_Calculator_Without_Allocations tests that lookups within the precomputed years do not allocate.
+func (s *Suite) Test_Precomputed_Calculator_Without_Allocations() {
+	precomputed, err := local_days.NewPrecomputedLocalDaysCalculator(local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin"), 2000, 2030)
+	then.AssertThat(s.T(), err, is.Nil())
+	timestamp := time.Date(2022, 3, 27, 12, 0, 0, 0, time.UTC)
+	allocations := testing.AllocsPerRun(100, func() {
+		precomputed.StartOfLocalDay(timestamp)
+		precomputed.StartOfNextLocalDay(timestamp)
+		precomputed.StartOfLocalMonth(timestamp)
+		precomputed.StartOfNextLocalMonth(timestamp)
+		precomputed.IsLocalMidnight(timestamp)
+	})
+	then.AssertThat(s.T(), allocations, is.EqualTo(0.0))
+}
+
+// Test_Precomputed_Calculator_Invalid_Arguments tests that unsupported calculators and year ranges are rejected.
+func (s *Suite) Test_Precomputed_Calculator_Invalid_Arguments() {
+	berlin := local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin")
+	for _, years := range [][2]int{{2030, 2020}, {1600, 2020}, {2020, 2300}} {
+		_, err := local_days.NewPrecomputedLocalDaysCalculator(berlin, years[0], years[1])
+		then.AssertThat(s.T(), errors.Is(err, local_days.ErrInvalidArgument), is.True())
+	}
+	_, err := local_days.NewPrecomputedLocalDaysCalculator(local_days.NewInstrumentedLocalDaysCalculator(berlin, local_days.CallHookFunc(func(local_days.Call) {})), 2020, 2030)
+	then.AssertThat(s.T(), errors.Is(err, local_days.ErrInvalidArgument), is.True())
+	strict, err := local_days.NewPrecomputedLocalDaysCalculator(local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin", local_days.WithStrictInputValidation()), 2020, 2030)
+	then.AssertThat(s.T(), err, is.Nil())
+	s.Panics(func() { strict.StartOfLocalDay(time.Date(2022, 11, 16, 12, 0, 0, 0, time.FixedZone("CET", 60*60))) })
+}
+
+// BenchmarkStartOfLocalDay measures the calculation of local day starts using time.Location.
+func BenchmarkStartOfLocalDay(b *testing.B) {
+	benchmarkStartOfLocalDay(b, local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin"))
+}
+
+// BenchmarkPrecomputedStartOfLocalDay measures the lookup of precomputed local day starts.
+func BenchmarkPrecomputedStartOfLocalDay(b *testing.B) {
+	precomputed, err := local_days.NewPrecomputedLocalDaysCalculator(local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin"), 2000, 2030)
+	if err != nil {
+		b.Fatal(err)
+	}
+	benchmarkStartOfLocalDay(b, precomputed)
+}
+
+// BenchmarkAddLocalDays measures adding local days using time.Location.
+func BenchmarkAddLocalDays(b *testing.B) {
+	calculator := local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin")
+	timestamp := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		calculator.AddLocalDays(timestamp.Add(time.Duration(i%35040)*15*time.Minute), 1)
+	}
+}
+
+func benchmarkStartOfLocalDay(b *testing.B, calculator local_days.LocalDaysCalculator) {
+	timestamp := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// a quarter hour resolution as in meter data
+		calculator.StartOfNextLocalDay(calculator.StartOfLocalDay(timestamp.Add(time.Duration(i%35040) * 15 * time.Minute)))
+	}
+}
+
+/*****************
+ Bulk Operations
+*****************/
+
+// Test_Bulk_Operations tests that the bulk functions return the same results as the scalar methods for all kinds of calculators.
+func (s *Suite) Test_Bulk_Operations() {
+	berlin := local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin", local_days.WithDayStart(6*time.Hour))
+	precomputed, err := local_days.NewPrecomputedLocalDaysCalculator(berlin, 2022, 2022)
+	then.AssertThat(s.T(), err, is.Nil())
+	instrumented := local_days.NewInstrumentedLocalDaysCalculator(berlin, local_days.CallHookFunc(func(local_days.Call) {}))
+	var timestamps []time.Time
+	var unixSeconds []int64
+	// unsorted and partly outside of the precomputed year
+	for timestamp := time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC); timestamp.Before(time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)); timestamp = timestamp.Add(15 * time.Minute) {
+		timestamps = append(timestamps, timestamp)
+		unixSeconds = append(unixSeconds, timestamp.Unix())
+	}
+	timestamps = append(timestamps, time.Date(2022, 3, 27, 3, 0, 0, 0, time.UTC))
+	unixSeconds = append(unixSeconds, time.Date(2022, 3, 27, 3, 0, 0, 0, time.UTC).Unix())
+	for _, calculator := range []local_days.LocalDaysCalculator{berlin, precomputed, instrumented} {
+		starts := local_days.BulkStartOfLocalDay(calculator, timestamps, make([]time.Time, 0, len(timestamps)))
+		unixStarts := local_days.BulkStartOfLocalDayUnix(calculator, unixSeconds, nil)
+		dayIndexes, err := local_days.BulkLocalDayIndex(calculator, timestamps, nil)
+		then.AssertThat(s.T(), err, is.Nil())
+		unixDayIndexes, err := local_days.BulkLocalDayIndexUnix(calculator, unixSeconds, nil)
+		then.AssertThat(s.T(), err, is.Nil())
+		monthKeys, err := local_days.BulkLocalMonthKey(calculator, timestamps, nil)
+		then.AssertThat(s.T(), err, is.Nil())
+		unixMonthKeys, err := local_days.BulkLocalMonthKeyUnix(calculator, unixSeconds, nil)
+		then.AssertThat(s.T(), err, is.Nil())
+		for i, timestamp := range timestamps {
+			startOfDay := berlin.StartOfLocalDay(timestamp)
+			s.Equal(startOfDay, starts[i], timestamp.String())
+			s.Equal(startOfDay.Unix(), unixStarts[i], timestamp.String())
+			// the gas day starts on its local date at 06:00
+			date := local_days.DateOfLocalDayIndex(dayIndexes[i])
+			s.Equal(startOfDay, time.Date(date.Year(), date.Month(), date.Day(), 6, 0, 0, 0, local_days.Location(berlin)).UTC(), timestamp.String())
+			s.Equal(dayIndexes[i], unixDayIndexes[i], timestamp.String())
+			s.Equal(int32(date.Year()*100+int(date.Month())), monthKeys[i], timestamp.String())
+			s.Equal(monthKeys[i], unixMonthKeys[i], timestamp.String())
+		}
+	}
+	monthKeys, _ := local_days.BulkLocalMonthKey(berlin, []time.Time{time.Date(2022, 11, 1, 4, 59, 0, 0, time.UTC), time.Date(2022, 11, 1, 5, 0, 0, 0, time.UTC)}, nil)
+	then.AssertThat(s.T(), monthKeys, is.EqualTo([]int32{202210, 202211}))
+}
+
+// Test_Bulk_Operations_Reuse_Buffers tests that the buffers of the caller are reused.
+func (s *Suite) Test_Bulk_Operations_Reuse_Buffers() {
+	berlin := local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin")
+	buffer := make([]int32, 10)
+	dayIndexes, err := local_days.BulkLocalDayIndex(berlin, []time.Time{time.Date(1970, 1, 1, 12, 0, 0, 0, time.UTC), time.Date(1970, 1, 2, 12, 0, 0, 0, time.UTC)}, buffer)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), dayIndexes, is.EqualTo([]int32{0, 1}))
+	then.AssertThat(s.T(), &dayIndexes[0] == &buffer[0], is.True())
+}
+
+// Test_Bulk_Operations_Without_Location tests that local day indexes are rejected for calculators whose local dates are unknown.
+func (s *Suite) Test_Bulk_Operations_Without_Location() {
+	custom := panickingCalculator{local_days.NewConverterBasedLocalDaysCalculator(simpleSummerTimeConverter{})}
+	_, err := local_days.BulkLocalMonthKey(custom, []time.Time{time.Date(2022, 11, 16, 12, 0, 0, 0, time.UTC)}, nil)
+	then.AssertThat(s.T(), errors.Is(err, local_days.ErrInvalidArgument), is.True())
+	var invalidArgument *local_days.InvalidArgumentError
+	then.AssertThat(s.T(), errors.As(err, &invalidArgument), is.True())
+	then.AssertThat(s.T(), invalidArgument.Function, is.EqualTo("BulkLocalMonthKey"))
+	_, err = local_days.LocalDate(custom, time.Date(2022, 11, 16, 12, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), errors.Is(err, local_days.ErrInvalidArgument), is.True())
+}
+
+// Test_Local_Date tests that the local date respects the day start of the calculator.
+func (s *Suite) Test_Local_Date() {
+	gasDays := local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin", local_days.WithDayStart(6*time.Hour))
+	date, err := local_days.LocalDate(gasDays, time.Date(2022, 11, 16, 4, 59, 0, 0, time.UTC))
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), date, is.EqualTo(time.Date(2022, 11, 15, 0, 0, 0, 0, time.UTC)))
+	date, _ = local_days.LocalDate(gasDays, time.Date(2022, 11, 16, 5, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), date, is.EqualTo(time.Date(2022, 11, 16, 0, 0, 0, 0, time.UTC)))
+}
+
+// BenchmarkScalarStartOfLocalDay measures calling StartOfLocalDay for a year of quarter hours.
+func BenchmarkScalarStartOfLocalDay(b *testing.B) {
+	calculator, timestamps := benchmarkQuarterHours(b)
+	starts := make([]time.Time, len(timestamps))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j, timestamp := range timestamps {
+			starts[j] = calculator.StartOfLocalDay(timestamp)
+		}
+	}
+}
+
+// BenchmarkBulkStartOfLocalDay measures BulkStartOfLocalDay for a year of quarter hours.
+func BenchmarkBulkStartOfLocalDay(b *testing.B) {
+	calculator, timestamps := benchmarkQuarterHours(b)
+	starts := make([]time.Time, len(timestamps))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		starts = local_days.BulkStartOfLocalDay(calculator, timestamps, starts)
+	}
+}
+
+// BenchmarkBulkLocalMonthKeyUnix measures BulkLocalMonthKeyUnix for a year of quarter hours.
+func BenchmarkBulkLocalMonthKeyUnix(b *testing.B) {
+	calculator, timestamps := benchmarkQuarterHours(b)
+	unixSeconds := make([]int64, len(timestamps))
+	for i, timestamp := range timestamps {
+		unixSeconds[i] = timestamp.Unix()
+	}
+	monthKeys := make([]int32, len(timestamps))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		monthKeys, _ = local_days.BulkLocalMonthKeyUnix(calculator, unixSeconds, monthKeys)
+	}
+}
+
+// benchmarkQuarterHours returns a precomputed calculator and the quarter hours of 2022.
+func benchmarkQuarterHours(b *testing.B) (local_days.LocalDaysCalculator, []time.Time) {
+	calculator, err := local_days.NewPrecomputedLocalDaysCalculator(local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin"), 2020, 2024)
+	if err != nil {
+		b.Fatal(err)
+	}
+	var timestamps []time.Time
+	for timestamp := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC); timestamp.Before(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)); timestamp = timestamp.Add(15 * time.Minute) {
+		timestamps = append(timestamps, timestamp)
+	}
+	return calculator, timestamps
+}
+
+/************************
+ Comparing Calculators
+************************/
+
+// Test_Compare_Calculators_Abolished_DST tests the differences between the old rules of Mexico City (which abolished DST in 2022) and the current tzdata.
+func (s *Suite) Test_Compare_Calculators_Abolished_DST() {
+	oldRules, err := local_days.NewPOSIXTZBasedLocalTimeConverter("CST6CDT,M4.1.0,M10.5.0")
+	then.AssertThat(s.T(), err, is.Nil())
+	mexicoCity := local_days.NewTimeZoneBasedLocalTimeConverter("America/Mexico_City")
+	differences := local_days.CompareCalculators(oldRules, mexicoCity, time.Date(2022, 1, 1, 6, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), differences.IsEmpty(), is.False())
+	// in 2022 the old rules still applied, so only the days from 2023-04-03 (the first full day of CDT) until 2023-10-29 (the day on which CDT ends) differ
+	then.AssertThat(s.T(), len(differences.LocalDays), is.EqualTo(210))
+	then.AssertThat(s.T(), differences.LocalDays[0], is.EqualTo(local_days.StartDifference{
+		Reference: time.Date(2023, 4, 3, 5, 0, 0, 0, time.UTC),
+		Other:     time.Date(2023, 4, 3, 6, 0, 0, 0, time.UTC),
+	}))
+	// May until October
+	then.AssertThat(s.T(), len(differences.LocalMonths), is.EqualTo(6))
+	then.AssertThat(s.T(), differences.LocalMonths[0].Reference, is.EqualTo(time.Date(2023, 5, 1, 5, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), differences.LocalMonths[0].Shift(), is.EqualTo(time.Hour))
+}
+
+// Test_Compare_Calculators_No_Differences tests that the same rules do not differ.
+func (s *Suite) Test_Compare_Calculators_No_Differences() {
+	posixBerlin, err := local_days.NewPOSIXTZBasedLocalTimeConverter("CET-1CEST,M3.5.0,M10.5.0/3")
+	then.AssertThat(s.T(), err, is.Nil())
+	berlin := local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin")
+	differences := local_days.CompareCalculators(posixBerlin, berlin, time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), differences.IsEmpty(), is.True())
+}
+
+// setEnv sets the environment variable key to value and returns a function that restores the previous value.
+func (s *Suite) setEnv(key string, value string) func() {
+	previous, wasSet := os.LookupEnv(key)
+	then.AssertThat(s.T(), os.Setenv(key, value), is.Nil())
+	return func() {
+		if wasSet {
+			_ = os.Setenv(key, previous)
+		} else {
+			_ = os.Unsetenv(key)
+		}
+	}
+}
+
+// readTZData returns the TZif data for zoneName from the embedded tzdata, so that the tests don't depend on the tzdata installed on the system.
+func (s *Suite) readTZData(zoneName string) []byte {
+	data, err := embedded_tzdata.TZData(zoneName)
+	then.AssertThat(s.T(), err, is.Nil())
+	return data
+}
+
+/************************************
+ Skipped and Repeated Local Midnights
+************************************/
+
+// Test_Skipped_Midnight tests a local day whose midnight is skipped because the clocks are set forward from 00:00 to 01:00 (America/Santiago on 2022-09-11).
+func (s *Suite) Test_Skipped_Midnight() {
+	santiago := local_days.NewTimeZoneBasedLocalTimeConverter("America/Santiago")
+	startOfDay := time.Date(2022, 9, 11, 4, 0, 0, 0, time.UTC) // 01:00 -03
+	then.AssertThat(s.T(), santiago.StartOfLocalDay(time.Date(2022, 9, 11, 12, 0, 0, 0, time.UTC)), is.EqualTo(startOfDay))
+	then.AssertThat(s.T(), santiago.StartOfNextLocalDay(time.Date(2022, 9, 10, 12, 0, 0, 0, time.UTC)), is.EqualTo(startOfDay))
+	then.AssertThat(s.T(), santiago.IsLocalMidnight(startOfDay), is.True())
+	then.AssertThat(s.T(), santiago.NextLocalWeekday(time.Date(2022, 9, 10, 12, 0, 0, 0, time.UTC), time.Sunday), is.EqualTo(startOfDay))
+	// 00:30 local time does not exist on 2022-09-11, so the result is shifted forward by the length of the gap to 01:30 -03
+	then.AssertThat(s.T(), santiago.AddLocalDays(time.Date(2022, 9, 10, 4, 30, 0, 0, time.UTC), 1), is.EqualTo(time.Date(2022, 9, 11, 4, 30, 0, 0, time.UTC)))
+}
+
+// Test_Repeated_Midnight tests a local day whose midnight occurs twice because the clocks are set back from 01:00 to 00:00 (Atlantic/Azores on 2022-10-30).
+func (s *Suite) Test_Repeated_Midnight() {
+	azores := local_days.NewTimeZoneBasedLocalTimeConverter("Atlantic/Azores")
+	startOfDay := time.Date(2022, 10, 30, 0, 0, 0, 0, time.UTC) // the first 00:00, which is +00
+	then.AssertThat(s.T(), azores.StartOfLocalDay(time.Date(2022, 10, 30, 12, 0, 0, 0, time.UTC)), is.EqualTo(startOfDay))
+	then.AssertThat(s.T(), azores.IsLocalMidnight(startOfDay), is.True())
+	then.AssertThat(s.T(), azores.IsLocalMidnight(startOfDay.Add(time.Hour)), is.False()) // the second 00:00, which is -01
+	then.AssertThat(s.T(), azores.NextLocalWeekday(time.Date(2022, 10, 29, 12, 0, 0, 0, time.UTC), time.Sunday), is.EqualTo(startOfDay))
+	then.AssertThat(s.T(), azores.AddLocalDays(time.Date(2022, 10, 29, 0, 0, 0, 0, time.UTC), 1), is.EqualTo(startOfDay))
+}
+
+// Test_Skipped_Date tests that NextLocalWeekday does not stop at a local date that is skipped entirely (Friday, 2011-12-30 in Pacific/Apia).
+func (s *Suite) Test_Skipped_Date() {
+	apia := local_days.NewTimeZoneBasedLocalTimeConverter("Pacific/Apia")
+	thursday := time.Date(2011, 12, 29, 22, 0, 0, 0, time.UTC) // 12:00 -10
+	then.AssertThat(s.T(), apia.GetLocalWeekday(thursday), is.EqualTo(time.Thursday))
+	then.AssertThat(s.T(), apia.StartOfNextLocalDay(thursday), is.EqualTo(time.Date(2011, 12, 30, 10, 0, 0, 0, time.UTC))) // Saturday, 2011-12-31 00:00 +14
+	then.AssertThat(s.T(), apia.NextLocalWeekday(thursday, time.Friday), is.EqualTo(time.Date(2012, 1, 5, 10, 0, 0, 0, time.UTC)))
+}
+
+/*******************
+ Local Clock Times
+*******************/
+
+// Test_Local_Clock_Times tests that skipped and repeated local clock times are found.
+func (s *Suite) Test_Local_Clock_Times() {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), local_days.LocalClockTimes(berlin, time.Date(2022, 11, 16, 0, 0, 0, 0, time.UTC), 6*time.Hour), is.EqualTo([]time.Time{time.Date(2022, 11, 16, 5, 0, 0, 0, time.UTC)}))
+	// 02:30 is skipped at the start of DST, so it starts when the clocks are set forward from 02:00 to 03:00
+	then.AssertThat(s.T(), local_days.LocalClockTimes(berlin, time.Date(2022, 3, 27, 0, 0, 0, 0, time.UTC), 150*time.Minute), is.EqualTo([]time.Time{time.Date(2022, 3, 27, 1, 0, 0, 0, time.UTC)}))
+	then.AssertThat(s.T(), local_days.LocalClockTimes(berlin, time.Date(2022, 10, 30, 0, 0, 0, 0, time.UTC), 150*time.Minute), is.EqualTo([]time.Time{time.Date(2022, 10, 30, 0, 30, 0, 0, time.UTC), time.Date(2022, 10, 30, 1, 30, 0, 0, time.UTC)}))
+	// 24:00 is midnight of the next day
+	then.AssertThat(s.T(), local_days.LocalClockTimes(berlin, time.Date(2022, 3, 26, 0, 0, 0, 0, time.UTC), 24*time.Hour), is.EqualTo([]time.Time{time.Date(2022, 3, 26, 23, 0, 0, 0, time.UTC)}))
+	casey, err := time.LoadLocation("Antarctica/Casey")
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), local_days.LocalClockTimes(casey, time.Date(2010, 3, 5, 0, 0, 0, 0, time.UTC), 0), is.EqualTo([]time.Time{time.Date(2010, 3, 4, 13, 0, 0, 0, time.UTC), time.Date(2010, 3, 4, 16, 0, 0, 0, time.UTC)}))
+}
+
+// Test_Offset_Transitions tests that the changes of the UTC offset are found to the second.
+func (s *Suite) Test_Offset_Transitions() {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	then.AssertThat(s.T(), err, is.Nil())
+	transitions := local_days.OffsetTransitions(berlin, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), transitions, is.EqualTo([]time.Time{time.Date(2022, 3, 27, 1, 0, 0, 0, time.UTC), time.Date(2022, 10, 30, 1, 0, 0, 0, time.UTC)}))
+	then.AssertThat(s.T(), local_days.OffsetTransitions(berlin, time.Date(2022, 3, 27, 1, 0, 0, 0, time.UTC), time.Date(2022, 3, 28, 0, 0, 0, 0, time.UTC)), is.Empty())
+	then.AssertThat(s.T(), local_days.OffsetTransitions(berlin, time.Date(2022, 3, 27, 0, 59, 59, 999999999, time.UTC), time.Date(2022, 3, 27, 1, 0, 0, 0, time.UTC)), is.EqualTo([]time.Time{time.Date(2022, 3, 27, 1, 0, 0, 0, time.UTC)}))
+}
+
+// ----------------------------
+// test framework boiler plate
+// ---------------------------
+type Suite struct {
+	suite.Suite
+}
+
+// SetupSuite sets up the tests
+func (s *Suite) SetupSuite() {
+}
+
+func (s *Suite) AfterTest(_, _ string) {
+}
+
+func TestInit(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
@@ -0,0 +1,105 @@
+package local_days
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// Option configures optional behaviour of a LocalDaysCalculator. All constructors accept options, e.g.
+//
+//	gasDays := local_days.NewTimeZoneBasedLocalTimeConverter("Europe/Berlin", local_days.WithDayStart(6*time.Hour))
+type Option func(*converterBasedLocalDaysCalculator)
+
+// WithDayStart lets local days start at the given local time (as offset from local midnight) instead of midnight, e.g. 6*time.Hour for the gas day which starts at 06:00 local time.
+// It affects all local days, weeks and months: The local day of a timestamp before the day start is the previous day, and the local months start at the day start of their first day.
+// It panics if dayStart is negative or not less than 24h.
+func WithDayStart(dayStart time.Duration) Option {
+	if dayStart < 0 || dayStart >= 24*time.Hour {
+		log.Panic(fmt.Errorf("The day start %s is not between 0 (inclusive) and 24h (exclusive)", dayStart))
+	}
+	return func(c *converterBasedLocalDaysCalculator) {
+		c.dayStart = dayStart
+	}
+}
+
+// WithWeekStart sets the first day of local weeks. By default, weeks start on Monday (as in ISO 8601). It panics if weekStart is not a valid time.Weekday.
+func WithWeekStart(weekStart time.Weekday) Option {
+	if err := validateWeekday("WithWeekStart", weekStart); err != nil {
+		log.Panic(err)
+	}
+	return func(c *converterBasedLocalDaysCalculator) {
+		c.weekStart = weekStart
+	}
+}
+
+// WithStrictInputValidation makes the calculator panic if it's called with a timestamp that is not in UTC or carries a monotonic clock reading (see ValidateTimestamp). Use it to enforce the convention that your application only uses UTC internally.
+func WithStrictInputValidation() Option {
+	return func(c *converterBasedLocalDaysCalculator) {
+		c.strictInputValidation = true
+	}
+}
+
+// WithHolidayCalendar sets the holidays that are considered by IsLocalHoliday and IsLocalBusinessDay. By default, there are no holidays.
+func WithHolidayCalendar(holidays HolidayCalendar) Option {
+	return func(c *converterBasedLocalDaysCalculator) {
+		c.holidays = holidays
+		c.holidayRegion = ""
+	}
+}
+
+// WithHolidayRegion is like WithHolidayCalendar but uses the calendar registered for region (see RegisterHolidayRegion). Other than WithHolidayCalendar, the region is part of the Spec of the calculator.
+// It panics if no calendar is registered for region.
+func WithHolidayRegion(region string) Option {
+	holidays, ok := lookupHolidayRegion(region)
+	if !ok {
+		log.Panic(fmt.Errorf("There is no holiday calendar registered for the region '%s'", region))
+	}
+	return func(c *converterBasedLocalDaysCalculator) {
+		c.holidays = holidays
+		c.holidayRegion = region
+	}
+}
+
+// WithClock sets the clock that is used by Now. By default, it's time.Now. Use it for deterministic tests.
+func WithClock(now func() time.Time) Option {
+	if now == nil {
+		log.Panic(fmt.Errorf("The clock must not be nil"))
+	}
+	return func(c *converterBasedLocalDaysCalculator) {
+		c.now = now
+	}
+}
+
+// ValidateTimestamp returns a *TimestampError if timestamp does not follow the convention of this package, i.e. if it's not in UTC or if it carries a monotonic clock reading (like the results of time.Now()). Use NormalizeTimestamp to convert it.
+// Calculators that are configured with WithStrictInputValidation panic with this error.
+func ValidateTimestamp(timestamp time.Time) error {
+	if timestamp != timestamp.Round(0) {
+		return &TimestampError{Timestamp: timestamp, Err: ErrMonotonicClockReading}
+	}
+	if timestamp.Location() != time.UTC {
+		return &TimestampError{Timestamp: timestamp, Err: ErrNotUTC}
+	}
+	return nil
+}
+
+// NormalizeTimestamp returns the same point in time as timestamp in UTC and without monotonic clock reading, so that it passes ValidateTimestamp and can be compared using ==. All timestamps returned by the calculators of this package are normalized.
+func NormalizeTimestamp(timestamp time.Time) time.Time {
+	// UTC strips the monotonic clock reading, too
+	return timestamp.UTC()
+}
+
+// validateInput panics if the calculator is configured with WithStrictInputValidation and timestamp is invalid according to ValidateTimestamp.
+// It's kept small enough to be inlined, so that calculators without strict input validation don't pay for it.
+func (c *converterBasedLocalDaysCalculator) validateInput(timestamp time.Time) {
+	if c.strictInputValidation {
+		validateStrictInput(timestamp)
+	}
+}
+
+// validateStrictInput panics if timestamp is invalid according to ValidateTimestamp.
+func validateStrictInput(timestamp time.Time) {
+	if err := ValidateTimestamp(timestamp); err != nil {
+		log.Panic(err)
+	}
+}
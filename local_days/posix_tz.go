@@ -0,0 +1,204 @@
+package local_days
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// posixTZ is the relevant part of a parsed POSIX TZ string like "CET-1CEST,M3.5.0,M10.5.0/3".
+type posixTZ struct {
+	// standardName is the abbreviation of the standard time (e.g. "CET")
+	standardName string
+	// standardOffset is the offset of the standard time in seconds east of UTC (note that POSIX uses the opposite sign)
+	standardOffset int
+}
+
+// parsePOSIXTZ parses and validates a POSIX TZ string as described in https://pubs.opengroup.org/onlinepubs/9699919799/basedefs/V1_chap08.html and extended by RFC 8536 section 3.3.1.
+// Other than the POSIX standard, the rules for daylight saving time are required if a daylight saving time is specified because there are no sensible defaults.
+func parsePOSIXTZ(tz string) (posixTZ, error) {
+	p := posixTZParser{rest: tz}
+	result := posixTZ{}
+	var err error
+	if result.standardName, err = p.name(); err != nil {
+		return posixTZ{}, err
+	}
+	if result.standardOffset, err = p.offset(24); err != nil {
+		return posixTZ{}, err
+	}
+	result.standardOffset = -result.standardOffset
+	if p.rest == "" {
+		return result, nil
+	}
+	if _, err = p.name(); err != nil {
+		return posixTZ{}, err
+	}
+	if p.rest != "" && p.rest[0] != ',' {
+		if _, err = p.offset(24); err != nil {
+			return posixTZ{}, err
+		}
+	}
+	for _, rule := range []string{"start", "end"} {
+		if !p.consume(",") {
+			return posixTZ{}, fmt.Errorf("the %s rule of the daylight saving time is missing", rule)
+		}
+		if err = p.rule(); err != nil {
+			return posixTZ{}, err
+		}
+	}
+	if p.rest != "" {
+		return posixTZ{}, fmt.Errorf("unexpected '%s' at the end", p.rest)
+	}
+	return result, nil
+}
+
+// posixTZParser consumes a POSIX TZ string from left to right.
+type posixTZParser struct {
+	rest string
+}
+
+func (p *posixTZParser) consume(prefix string) bool {
+	if strings.HasPrefix(p.rest, prefix) {
+		p.rest = p.rest[len(prefix):]
+		return true
+	}
+	return false
+}
+
+// name parses a zone abbreviation which is either at least 3 letters (e.g. "CET") or at least 3 alphanumeric characters, '+' or '-' in angle brackets (e.g. "<+03>").
+func (p *posixTZParser) name() (string, error) {
+	if p.consume("<") {
+		end := strings.IndexByte(p.rest, '>')
+		if end < 0 {
+			return "", fmt.Errorf("the '>' of the quoted name is missing")
+		}
+		name := p.rest[:end]
+		p.rest = p.rest[end+1:]
+		if len(name) < 3 || strings.IndexFunc(name, func(r rune) bool { return !isLetter(r) && !isDigit(r) && r != '+' && r != '-' }) >= 0 {
+			return "", fmt.Errorf("the quoted name '<%s>' is invalid", name)
+		}
+		return name, nil
+	}
+	end := strings.IndexFunc(p.rest, func(r rune) bool { return !isLetter(r) })
+	if end < 0 {
+		end = len(p.rest)
+	}
+	if end < 3 {
+		return "", fmt.Errorf("expected a name of at least 3 letters at '%s'", p.rest)
+	}
+	name := p.rest[:end]
+	p.rest = p.rest[end:]
+	return name, nil
+}
+
+// offset parses a signed [+-]hh[:mm[:ss]] value with hours up to maxHours and returns it in seconds.
+func (p *posixTZParser) offset(maxHours int) (int, error) {
+	sign := 1
+	if p.consume("-") {
+		sign = -1
+	} else {
+		p.consume("+")
+	}
+	hours, err := p.number(0, maxHours, "hours")
+	if err != nil {
+		return 0, err
+	}
+	seconds := hours * 3600
+	for _, unit := range []struct {
+		name   string
+		factor int
+	}{{"minutes", 60}, {"seconds", 1}} {
+		if !p.consume(":") {
+			break
+		}
+		value, err := p.number(0, 59, unit.name)
+		if err != nil {
+			return 0, err
+		}
+		seconds += value * unit.factor
+	}
+	return sign * seconds, nil
+}
+
+// rule parses the date (Jn, n or Mm.w.d) and the optional time (/[+-]hh[:mm[:ss]]) at which daylight saving time starts or ends.
+func (p *posixTZParser) rule() error {
+	var err error
+	switch {
+	case p.consume("J"):
+		_, err = p.number(1, 365, "julian day")
+	case p.consume("M"):
+		if _, err = p.number(1, 12, "month"); err != nil {
+			return err
+		}
+		if !p.consume(".") {
+			return fmt.Errorf("expected '.' at '%s'", p.rest)
+		}
+		if _, err = p.number(1, 5, "week"); err != nil {
+			return err
+		}
+		if !p.consume(".") {
+			return fmt.Errorf("expected '.' at '%s'", p.rest)
+		}
+		_, err = p.number(0, 6, "weekday")
+	default:
+		_, err = p.number(0, 365, "zero-based julian day")
+	}
+	if err != nil {
+		return err
+	}
+	if p.consume("/") {
+		_, err = p.offset(167)
+	}
+	return err
+}
+
+// number parses an unsigned decimal number between min and max (both inclusive).
+func (p *posixTZParser) number(min int, max int, description string) (int, error) {
+	end := strings.IndexFunc(p.rest, func(r rune) bool { return !isDigit(r) })
+	if end < 0 {
+		end = len(p.rest)
+	}
+	value, err := strconv.Atoi(p.rest[:end])
+	if err != nil || value < min || value > max {
+		return 0, fmt.Errorf("expected %s between %d and %d at '%s'", description, min, max, p.rest)
+	}
+	p.rest = p.rest[end:]
+	return value, nil
+}
+
+func isLetter(r rune) bool {
+	return ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z')
+}
+
+func isDigit(r rune) bool {
+	return '0' <= r && r <= '9'
+}
+
+// loadLocationFromPOSIXTZ returns a location that follows the rules of the given POSIX TZ string for all points in time.
+// Go does not provide a parser for TZ strings, but it evaluates the TZ string in the footer of TZif data (RFC 8536) for all points in time after the last transition. So we build TZif data without any transitions.
+func loadLocationFromPOSIXTZ(tz string) (*time.Location, error) {
+	parsed, err := parsePOSIXTZ(tz)
+	if err != nil {
+		return nil, err
+	}
+	abbreviations := parsed.standardName + "\x00"
+	var data bytes.Buffer
+	// version 1 data is followed by version 2 data with the same content, then the footer
+	for i := 0; i < 2; i++ {
+		data.WriteString("TZif2")
+		data.Write(make([]byte, 15))
+		// UTC/local indicators, standard/wall indicators, leap seconds, transitions, local time types, abbreviation characters
+		for _, count := range []uint32{0, 0, 0, 0, 1, uint32(len(abbreviations))} {
+			_ = binary.Write(&data, binary.BigEndian, count)
+		}
+		_ = binary.Write(&data, binary.BigEndian, int32(parsed.standardOffset))
+		data.WriteByte(0) // not DST
+		data.WriteByte(0) // index of the abbreviation
+		data.WriteString(abbreviations)
+	}
+	data.WriteString("\n" + tz + "\n")
+	return time.LoadLocationFromTZData(tz, data.Bytes())
+}
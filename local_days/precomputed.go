@@ -0,0 +1,214 @@
+package local_days
+
+import (
+	"fmt"
+	"time"
+)
+
+// NewPrecomputedLocalDaysCalculator returns a LocalDaysCalculator that gives the same results as calculator, but precomputes the starts of all local days and months from the beginning of fromYear until the end of toYear.
+// Within this range StartOfLocalDay, StartOfNextLocalDay, StartOfLocalMonth, StartOfNextLocalMonth, StartOfLocalWeek, StartOfNextLocalWeek, GetLocalWeekday, NextLocalWeekday and IsLocalMidnight answer in constant time and without allocations; outside of it (and for all other methods) the calls are delegated to calculator.
+// The tables take 20 bytes per local day. calculator has to be created by one of the constructors of this package; it returns an *InvalidArgumentError otherwise or if the years are not in ascending order between 1678 and 2261 (the range of time.Time.UnixNano).
+func NewPrecomputedLocalDaysCalculator(calculator LocalDaysCalculator, fromYear int, toYear int) (LocalDaysCalculator, error) {
+	c, ok := calculator.(converterBasedLocalDaysCalculator)
+	if !ok {
+		return nil, &InvalidArgumentError{Function: "NewPrecomputedLocalDaysCalculator", Argument: "calculator", Value: fmt.Sprintf("%T", calculator), Err: fmt.Errorf("only calculators created by the constructors of this package can be precomputed")}
+	}
+	if fromYear < 1678 || toYear > 2261 || fromYear > toYear {
+		return nil, &InvalidArgumentError{Function: "NewPrecomputedLocalDaysCalculator", Argument: "fromYear, toYear", Value: fmt.Sprintf("%d, %d", fromYear, toYear), Err: fmt.Errorf("the years have to be in ascending order between 1678 and 2261")}
+	}
+	p := precomputedLocalDaysCalculator{converterBasedLocalDaysCalculator: c}
+	p.start = c.startOfLocalDay(fromYear, 1, 1)
+	p.end = c.startOfLocalDay(toYear+1, 1, 1)
+	monthStart := 0
+	for startOfDay := p.start; startOfDay.Before(p.end); startOfDay = c.StartOfNextLocalDay(startOfDay) {
+		day := c.localDay(startOfDay)
+		index := len(p.starts)
+		if index > 0 && day.Month() != p.dayAt(index-1).Month() {
+			for i := monthStart; i < index; i++ {
+				p.nextMonthStarts[i] = int32(index)
+			}
+			monthStart = index
+		}
+		p.starts = append(p.starts, startOfDay.UnixNano())
+		p.days = append(p.days, int32(day.Unix()/SecondsPerDay))
+		p.monthStarts = append(p.monthStarts, int32(monthStart))
+		p.nextMonthStarts = append(p.nextMonthStarts, 0)
+	}
+	for i := monthStart; i < len(p.starts); i++ {
+		p.nextMonthStarts[i] = int32(len(p.starts))
+	}
+	p.starts = append(p.starts, p.end.UnixNano())
+	return &p, nil
+}
+
+// SecondsPerDay is the number of seconds of a day without UTC offset transitions, e.g. of a day in UTC.
+const SecondsPerDay = 24 * 60 * 60
+
+// precomputedLocalDaysCalculator answers the calls from tables of local days and delegates to the embedded calculator for timestamps outside of [start, end).
+type precomputedLocalDaysCalculator struct {
+	converterBasedLocalDaysCalculator
+	// start is the start of the first local day in the tables
+	start time.Time
+	// end is the start of the first local day after the tables
+	end time.Time
+	// starts are the starts of the local days as unix nanoseconds, followed by end
+	starts []int64
+	// days are the local dates of the local days as days since 1970-01-01
+	days []int32
+	// monthStarts are the indexes of the first local day in the month of each local day
+	monthStarts []int32
+	// nextMonthStarts are the indexes of the first local day in the month after the month of each local day (which might be len(days))
+	nextMonthStarts []int32
+}
+
+// index returns the index of the local day of timestamp or false if it's outside of the tables.
+func (p *precomputedLocalDaysCalculator) index(timestamp time.Time) (int, bool) {
+	if timestamp.Before(p.start) || !timestamp.Before(p.end) {
+		return 0, false
+	}
+	return p.indexOfNanos(timestamp.UnixNano()), true
+}
+
+// indexOfNanos returns the index of the local day of a timestamp given as unix nanoseconds within the tables.
+func (p *precomputedLocalDaysCalculator) indexOfNanos(nanos int64) int {
+	// local days are about 24h long, so the guess is at most a few days off
+	index := int((nanos - p.starts[0]) / int64(24*time.Hour))
+	if index >= len(p.days) {
+		index = len(p.days) - 1
+	}
+	for p.starts[index] > nanos {
+		index--
+	}
+	for p.starts[index+1] <= nanos {
+		index++
+	}
+	return index
+}
+
+// dayAt returns the local date of the local day with the given index as midnight UTC.
+func (p *precomputedLocalDaysCalculator) dayAt(index int) time.Time {
+	return time.Unix(int64(p.days[index])*SecondsPerDay, 0).UTC()
+}
+
+// startAt returns the start of the local day with the given index.
+func (p *precomputedLocalDaysCalculator) startAt(index int) time.Time {
+	return time.Unix(0, p.starts[index]).UTC()
+}
+
+// weekdayAt returns the weekday of the local day with the given index.
+func (p *precomputedLocalDaysCalculator) weekdayAt(index int) time.Weekday {
+	// 1970-01-01 was a Thursday
+	return time.Weekday((p.days[index]%7 + 7 + int32(time.Thursday)) % 7)
+}
+
+// firstIndexFrom returns the index of the first local day whose date is not before day, starting the search at index. It returns false if the result is not within the tables.
+func (p *precomputedLocalDaysCalculator) firstIndexFrom(index int, day int32) (int, bool) {
+	index += int(day - p.days[index])
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(p.days) {
+		index = len(p.days) - 1
+	}
+	for index > 0 && p.days[index-1] >= day {
+		index--
+	}
+	for p.days[index] < day {
+		index++
+		if index == len(p.days) {
+			return 0, false
+		}
+	}
+	if index == 0 && p.days[0] != day {
+		// the day might be before the tables
+		return 0, false
+	}
+	return index, true
+}
+
+func (p *precomputedLocalDaysCalculator) StartOfLocalDay(timestamp time.Time) time.Time {
+	index, ok := p.index(timestamp)
+	if !ok {
+		return p.converterBasedLocalDaysCalculator.StartOfLocalDay(timestamp)
+	}
+	p.validateInput(timestamp)
+	return p.startAt(index)
+}
+
+func (p *precomputedLocalDaysCalculator) StartOfNextLocalDay(timestamp time.Time) time.Time {
+	index, ok := p.index(timestamp)
+	if !ok {
+		return p.converterBasedLocalDaysCalculator.StartOfNextLocalDay(timestamp)
+	}
+	p.validateInput(timestamp)
+	return p.startAt(index + 1)
+}
+
+func (p *precomputedLocalDaysCalculator) StartOfLocalMonth(timestamp time.Time) time.Time {
+	index, ok := p.index(timestamp)
+	if !ok {
+		return p.converterBasedLocalDaysCalculator.StartOfLocalMonth(timestamp)
+	}
+	p.validateInput(timestamp)
+	return p.startAt(int(p.monthStarts[index]))
+}
+
+func (p *precomputedLocalDaysCalculator) StartOfNextLocalMonth(timestamp time.Time) time.Time {
+	index, ok := p.index(timestamp)
+	if !ok {
+		return p.converterBasedLocalDaysCalculator.StartOfNextLocalMonth(timestamp)
+	}
+	p.validateInput(timestamp)
+	return p.startAt(int(p.nextMonthStarts[index]))
+}
+
+func (p *precomputedLocalDaysCalculator) StartOfLocalWeek(timestamp time.Time) time.Time {
+	if index, ok := p.index(timestamp); ok {
+		if weekStart, ok := p.firstIndexFrom(index, p.days[index]-int32(p.daysSinceStartOfWeek(p.dayAt(index)))); ok {
+			p.validateInput(timestamp)
+			return p.startAt(weekStart)
+		}
+	}
+	return p.converterBasedLocalDaysCalculator.StartOfLocalWeek(timestamp)
+}
+
+func (p *precomputedLocalDaysCalculator) StartOfNextLocalWeek(timestamp time.Time) time.Time {
+	if index, ok := p.index(timestamp); ok {
+		if nextWeekStart, ok := p.firstIndexFrom(index, p.days[index]-int32(p.daysSinceStartOfWeek(p.dayAt(index)))+7); ok {
+			p.validateInput(timestamp)
+			return p.startAt(nextWeekStart)
+		}
+	}
+	return p.converterBasedLocalDaysCalculator.StartOfNextLocalWeek(timestamp)
+}
+
+func (p *precomputedLocalDaysCalculator) GetLocalWeekday(timestamp time.Time) time.Weekday {
+	index, ok := p.index(timestamp)
+	if !ok {
+		return p.converterBasedLocalDaysCalculator.GetLocalWeekday(timestamp)
+	}
+	p.validateInput(timestamp)
+	return p.weekdayAt(index)
+}
+
+func (p *precomputedLocalDaysCalculator) NextLocalWeekday(timestamp time.Time, weekday time.Weekday) time.Time {
+	if index, ok := p.index(timestamp); ok && weekday >= time.Sunday && weekday <= time.Saturday {
+		// usually it's one of the next 7 local days, but it might be later if a local date is skipped
+		for next := index + 1; next < len(p.days); next++ {
+			if p.weekdayAt(next) == weekday {
+				p.validateInput(timestamp)
+				return p.startAt(next)
+			}
+		}
+	}
+	return p.converterBasedLocalDaysCalculator.NextLocalWeekday(timestamp, weekday)
+}
+
+func (p *precomputedLocalDaysCalculator) IsLocalMidnight(timestamp time.Time) bool {
+	index, ok := p.index(timestamp)
+	if !ok {
+		return p.converterBasedLocalDaysCalculator.IsLocalMidnight(timestamp)
+	}
+	p.validateInput(timestamp)
+	return p.starts[index] == timestamp.UnixNano()
+}
@@ -0,0 +1,58 @@
+package local_days
+
+import (
+	"sync"
+)
+
+// Registry caches LocalDaysCalculators by their CalculatorSpec, so that the tzdata of a zone is loaded only once instead of on every call of NewTimeZoneBasedLocalTimeConverter.
+// It's safe for concurrent use. The zero value is an empty Registry, just like the one returned by NewRegistry.
+type Registry struct {
+	mutex       sync.RWMutex
+	calculators map[CalculatorSpec]LocalDaysCalculator
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{calculators: map[CalculatorSpec]LocalDaysCalculator{}}
+}
+
+// DefaultRegistry is the Registry used by the package level function Get.
+var DefaultRegistry = NewRegistry()
+
+// Get returns the cached calculator for the time zone with the given zoneName (e.g. "Europe/Berlin") from the DefaultRegistry. See Registry.Get.
+func Get(zoneName string) (LocalDaysCalculator, error) {
+	return DefaultRegistry.Get(zoneName)
+}
+
+// Get returns the cached calculator for the time zone with the given zoneName (e.g. "Europe/Berlin"), which is equivalent to NewTimeZoneBasedLocalTimeConverter(zoneName). All calls with the same zoneName return the same instance.
+// Other than NewTimeZoneBasedLocalTimeConverter it returns an error if the tzdata for the zone is not available.
+func (r *Registry) Get(zoneName string) (LocalDaysCalculator, error) {
+	return r.GetSpec(CalculatorSpec{ZoneName: zoneName})
+}
+
+// GetSpec returns the cached calculator built from spec (see CalculatorSpec.Build). All calls with equal specs return the same instance; errors are not cached.
+// Note that the calculator keeps using the holiday calendar that was registered for spec.HolidayRegion at the time it has been built.
+func (r *Registry) GetSpec(spec CalculatorSpec) (LocalDaysCalculator, error) {
+	r.mutex.RLock()
+	calculator, ok := r.calculators[spec]
+	r.mutex.RUnlock()
+	if ok {
+		return calculator, nil
+	}
+	// building the calculator loads the tzdata, which is slow, so we don't block the other specs meanwhile
+	calculator, err := spec.Build()
+	if err != nil {
+		return nil, err
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	// another goroutine might have built the calculator in the meantime. All callers have to get the same instance, so the first one wins
+	if cached, ok := r.calculators[spec]; ok {
+		return cached, nil
+	}
+	if r.calculators == nil {
+		r.calculators = map[CalculatorSpec]LocalDaysCalculator{}
+	}
+	r.calculators[spec] = calculator
+	return calculator, nil
+}
@@ -0,0 +1,170 @@
+package local_days
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CalculatorSpec is a serializable description of a LocalDaysCalculator, e.g. for reading the calendar behaviour of a service from its JSON or YAML configuration:
+//
+//	{"zoneName": "Europe/Berlin", "dayStart": "06:00", "holidayRegion": "DE"}
+//
+// Use Build to create the described calculator and SpecOf to describe an existing one. Exactly one of ZoneName and FixedOffset has to be set; all other fields are optional.
+type CalculatorSpec struct {
+	// ZoneName is the name of the IANA time zone, e.g. "Europe/Berlin" (see NewTimeZoneBasedLocalTimeConverter).
+	ZoneName string `json:"zoneName,omitempty" yaml:"zoneName,omitempty"`
+	// FixedOffset is the fixed offset from UTC as "[+-]hh:mm[:ss]", e.g. "+01:00" (see NewFixedOffsetLocalTimeConverter).
+	FixedOffset string `json:"fixedOffset,omitempty" yaml:"fixedOffset,omitempty"`
+	// DayStart is the local time at which local days start as "hh:mm[:ss]", e.g. "06:00" (see WithDayStart). Empty means midnight.
+	DayStart string `json:"dayStart,omitempty" yaml:"dayStart,omitempty"`
+	// WeekStart is the English name of the first day of local weeks, e.g. "Sunday" (see WithWeekStart). Empty means Monday.
+	WeekStart string `json:"weekStart,omitempty" yaml:"weekStart,omitempty"`
+	// HolidayRegion is the name of a holiday calendar registered with RegisterHolidayRegion (see WithHolidayRegion). Empty means no holidays.
+	HolidayRegion string `json:"holidayRegion,omitempty" yaml:"holidayRegion,omitempty"`
+	// StrictInputValidation rejects timestamps that are not in UTC (see WithStrictInputValidation).
+	StrictInputValidation bool `json:"strictInputValidation,omitempty" yaml:"strictInputValidation,omitempty"`
+}
+
+// SpecFieldError is returned by CalculatorSpec.Build and CalculatorSpec.Validate if a field of the spec is invalid.
+type SpecFieldError struct {
+	// Field is the JSON name of the invalid field, e.g. "dayStart"
+	Field string
+	// Value is the invalid value
+	Value string
+	// Err describes why the value is invalid
+	Err error
+}
+
+func (e *SpecFieldError) Error() string {
+	return fmt.Sprintf("The value '%s' of the field '%s' is invalid: %v", e.Value, e.Field, e.Err)
+}
+
+func (e *SpecFieldError) Unwrap() error {
+	return e.Err
+}
+
+// Validate returns a *SpecFieldError for the first invalid field of the spec or nil if Build would succeed.
+func (s CalculatorSpec) Validate() error {
+	_, err := s.Build()
+	return err
+}
+
+// Build returns the LocalDaysCalculator described by the spec. It returns a *SpecFieldError if a field is invalid.
+func (s CalculatorSpec) Build() (LocalDaysCalculator, error) {
+	options, err := s.options()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case s.ZoneName != "" && s.FixedOffset != "":
+		return nil, &SpecFieldError{Field: "fixedOffset", Value: s.FixedOffset, Err: fmt.Errorf("only one of zoneName and fixedOffset may be set")}
+	case s.ZoneName != "":
+		calculator, err := newTimeZoneBasedLocalDaysCalculator(s.ZoneName, options)
+		if err != nil {
+			return nil, &SpecFieldError{Field: "zoneName", Value: s.ZoneName, Err: err}
+		}
+		return calculator, nil
+	case s.FixedOffset != "":
+		offset, err := parseClock(s.FixedOffset, true)
+		if err != nil {
+			return nil, &SpecFieldError{Field: "fixedOffset", Value: s.FixedOffset, Err: err}
+		}
+		calculator, err := newFixedOffsetLocalDaysCalculator(offset, options)
+		if err != nil {
+			return nil, &SpecFieldError{Field: "fixedOffset", Value: s.FixedOffset, Err: err}
+		}
+		return calculator, nil
+	default:
+		return nil, &SpecFieldError{Field: "zoneName", Value: s.ZoneName, Err: fmt.Errorf("either zoneName or fixedOffset has to be set")}
+	}
+}
+
+// options returns the options described by the optional fields of the spec.
+func (s CalculatorSpec) options() ([]Option, error) {
+	var options []Option
+	if s.DayStart != "" {
+		dayStart, err := parseClock(s.DayStart, false)
+		if err != nil {
+			return nil, &SpecFieldError{Field: "dayStart", Value: s.DayStart, Err: err}
+		}
+		options = append(options, WithDayStart(dayStart))
+	}
+	if s.WeekStart != "" {
+		weekStart, err := ParseWeekday(s.WeekStart)
+		if err != nil {
+			return nil, &SpecFieldError{Field: "weekStart", Value: s.WeekStart, Err: err}
+		}
+		options = append(options, WithWeekStart(weekStart))
+	}
+	if s.HolidayRegion != "" {
+		if _, ok := lookupHolidayRegion(s.HolidayRegion); !ok {
+			return nil, &SpecFieldError{Field: "holidayRegion", Value: s.HolidayRegion, Err: fmt.Errorf("there is no holiday calendar registered for this region")}
+		}
+		options = append(options, WithHolidayRegion(s.HolidayRegion))
+	}
+	if s.StrictInputValidation {
+		options = append(options, WithStrictInputValidation())
+	}
+	return options, nil
+}
+
+// parseClock parses "hh:mm[:ss]" (or "[+-]hh:mm[:ss]" if signed is true) as duration of less than 24h.
+func parseClock(value string, signed bool) (time.Duration, error) {
+	if !signed && (strings.HasPrefix(value, "+") || strings.HasPrefix(value, "-")) {
+		return 0, fmt.Errorf("expected a time of day without sign")
+	}
+	p := posixTZParser{rest: value}
+	seconds, err := p.offset(23)
+	if err != nil {
+		return 0, err
+	}
+	if p.rest != "" {
+		return 0, fmt.Errorf("unexpected '%s' at the end", p.rest)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// ParseWeekday parses the English name of a weekday (case-insensitive), e.g. "Monday" or "sunday".
+func ParseWeekday(value string) (time.Weekday, error) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.EqualFold(weekday.String(), value) {
+			return weekday, nil
+		}
+	}
+	return 0, fmt.Errorf("expected the English name of a weekday, e.g. 'Monday'")
+}
+
+// SpecProvider is implemented by LocalDaysCalculators that can be described by a CalculatorSpec.
+type SpecProvider interface {
+	// Spec returns the CalculatorSpec that builds an equivalent calculator or an error if the calculator cannot be described by a CalculatorSpec.
+	Spec() (CalculatorSpec, error)
+}
+
+// SpecOf returns the CalculatorSpec that builds a calculator equivalent to calculator, so that SpecOf and CalculatorSpec.Build round-trip.
+// It returns an error if calculator cannot be described by a CalculatorSpec, e.g. because it's not based on a zone name or a fixed offset, or because it uses a holiday calendar that is not registered as region. The clock (see WithClock) is not part of the spec.
+func SpecOf(calculator LocalDaysCalculator) (CalculatorSpec, error) {
+	if provider, ok := calculator.(SpecProvider); ok {
+		return provider.Spec()
+	}
+	return CalculatorSpec{}, fmt.Errorf("The calculator %T cannot be described by a CalculatorSpec", calculator)
+}
+
+func (c converterBasedLocalDaysCalculator) Spec() (CalculatorSpec, error) {
+	if c.zoneSpec == nil {
+		return CalculatorSpec{}, fmt.Errorf("The calculator has not been created from a zone name or a fixed offset and cannot be described by a CalculatorSpec")
+	}
+	if c.holidays != nil && c.holidayRegion == "" {
+		return CalculatorSpec{}, fmt.Errorf("The holiday calendar of the calculator is not registered as holiday region and cannot be described by a CalculatorSpec")
+	}
+	spec := *c.zoneSpec
+	if c.dayStart != 0 {
+		spec.DayStart = formatClock(c.dayStart)
+	}
+	if c.weekStart != time.Monday {
+		spec.WeekStart = c.weekStart.String()
+	}
+	spec.HolidayRegion = c.holidayRegion
+	spec.StrictInputValidation = c.strictInputValidation
+	return spec, nil
+}
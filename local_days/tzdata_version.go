@@ -0,0 +1,77 @@
+package local_days
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// TZDataVersioner is implemented by LocalDaysCalculators that know the release of the IANA tzdata (e.g. "2024a") their time zone rules are taken from.
+type TZDataVersioner interface {
+	// TZDataVersion returns the release of the tzdata the calculator is based on or an empty string if it's unknown.
+	TZDataVersion() string
+}
+
+// TZDataVersion returns the release of the IANA tzdata (e.g. "2024a") that calculator is based on. It returns an empty string if the release is unknown, e.g. because the calculator does not implement TZDataVersioner or the tzdata was loaded from the time/tzdata package, which does not expose its release.
+func TZDataVersion(calculator LocalDaysCalculator) string {
+	if versioner, ok := calculator.(TZDataVersioner); ok {
+		return versioner.TZDataVersion()
+	}
+	return ""
+}
+
+func (c converterBasedLocalDaysCalculator) TZDataVersion() string {
+	return c.tzdataVersion
+}
+
+var (
+	systemTZDataVersionOnce sync.Once
+	systemTZDataVersionRead string
+)
+
+// systemTZDataVersion returns the release of the tzdata from which time.LoadLocation loads the zones or an empty string if it's unknown.
+// Like the time package, it assumes that ZONEINFO and the installed tzdata don't change while the program is running, so it reads the release only once.
+func systemTZDataVersion() string {
+	systemTZDataVersionOnce.Do(func() {
+		systemTZDataVersionRead = readSystemTZDataVersion()
+	})
+	return systemTZDataVersionRead
+}
+
+// readSystemTZDataVersion reads the release of the tzdata in the first of the directories that exists which time.LoadLocation searches on unix systems (in the same order).
+func readSystemTZDataVersion() string {
+	directories := []string{"/usr/share/zoneinfo/", "/usr/share/lib/zoneinfo/", "/usr/lib/locale/TZ/", "/etc/zoneinfo/"}
+	if zoneinfo := os.Getenv("ZONEINFO"); zoneinfo != "" {
+		if info, err := os.Stat(zoneinfo); err == nil && !info.IsDir() {
+			// the zones are probably loaded from a zip file, which does not contain any version information
+			return ""
+		}
+		directories = append([]string{zoneinfo}, directories...)
+	}
+	for _, directory := range directories {
+		if info, err := os.Stat(directory); err == nil && info.IsDir() {
+			return readTZDataVersion(directory)
+		}
+	}
+	return ""
+}
+
+// readTZDataVersion reads the release of the tzdata installed in directory from the "+VERSION" file or the header of the "tzdata.zi" file (which are created by the tzdata Makefile).
+func readTZDataVersion(directory string) string {
+	if content, err := ioutil.ReadFile(filepath.Join(directory, "+VERSION")); err == nil {
+		return strings.TrimSpace(string(content))
+	}
+	file, err := os.Open(filepath.Join(directory, "tzdata.zi"))
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	if scanner.Scan() && strings.HasPrefix(scanner.Text(), "# version ") {
+		return strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "# version "))
+	}
+	return ""
+}
@@ -0,0 +1,195 @@
+package local_days
+
+import (
+	"time"
+)
+
+// LocalDaysCalculatorV2 offers the same calculations as LocalDaysCalculator but never panics. Instead, all methods that process external input return an error, which can be inspected using errors.Is (e.g. ErrInvalidWeekday, ErrNotUTC) and errors.As (e.g. *InvalidArgumentError, *TimestampError).
+// Use it for calculators whose inputs come from outside of your application. Create it from a CalculatorSpec (see CalculatorSpec.BuildV2) or from an existing calculator (see NewLocalDaysCalculatorV2).
+type LocalDaysCalculatorV2 interface {
+	// AddLocalDays is like LocalDaysCalculator.AddLocalDays.
+	AddLocalDays(timestamp time.Time, number int) (time.Time, error)
+	// StartOfLocalDay is like LocalDaysCalculator.StartOfLocalDay.
+	StartOfLocalDay(timestamp time.Time) (time.Time, error)
+	// StartOfNextLocalDay is like LocalDaysCalculator.StartOfNextLocalDay.
+	StartOfNextLocalDay(timestamp time.Time) (time.Time, error)
+	// StartOfLocalMonth is like LocalDaysCalculator.StartOfLocalMonth.
+	StartOfLocalMonth(timestamp time.Time) (time.Time, error)
+	// StartOfNextLocalMonth is like LocalDaysCalculator.StartOfNextLocalMonth.
+	StartOfNextLocalMonth(timestamp time.Time) (time.Time, error)
+	// GetLocalWeekday is like LocalDaysCalculator.GetLocalWeekday.
+	GetLocalWeekday(timestamp time.Time) (time.Weekday, error)
+	// NextLocalWeekday is like LocalDaysCalculator.NextLocalWeekday but returns an *InvalidArgumentError (matching ErrInvalidWeekday) if weekday is not a valid time.Weekday.
+	NextLocalWeekday(timestamp time.Time, weekday time.Weekday) (time.Time, error)
+	// IsLocalMidnight is like LocalDaysCalculator.IsLocalMidnight.
+	IsLocalMidnight(timestamp time.Time) (bool, error)
+	// StartOfLocalWeek is like LocalWeeksCalculator.StartOfLocalWeek. If the wrapped calculator does not implement LocalWeeksCalculator, local weeks start on Monday.
+	StartOfLocalWeek(timestamp time.Time) (time.Time, error)
+	// StartOfNextLocalWeek is like LocalWeeksCalculator.StartOfNextLocalWeek (with the same fallback as StartOfLocalWeek).
+	StartOfNextLocalWeek(timestamp time.Time) (time.Time, error)
+	// IsLocalHoliday is like BusinessDaysCalculator.IsLocalHoliday. If the wrapped calculator does not implement BusinessDaysCalculator, there are no holidays.
+	IsLocalHoliday(timestamp time.Time) (bool, error)
+	// IsLocalBusinessDay is like BusinessDaysCalculator.IsLocalBusinessDay (with the same fallback as IsLocalHoliday).
+	IsLocalBusinessDay(timestamp time.Time) (bool, error)
+	// Now is like Clock.Now. If the wrapped calculator does not implement Clock, it returns the current system time in UTC.
+	Now() time.Time
+	// Location returns the location of the wrapped calculator (see the function Location).
+	Location() *time.Location
+	// ZoneName returns the zone name of the wrapped calculator (see the function ZoneName).
+	ZoneName() string
+	// V1 returns the wrapped LocalDaysCalculator, which panics instead of returning errors.
+	V1() LocalDaysCalculator
+}
+
+// NewLocalDaysCalculatorV2 returns a LocalDaysCalculatorV2 that delegates to calculator. Invalid inputs (including timestamps rejected by WithStrictInputValidation, also if calculator is a precomputed or instrumented calculator of this package) are checked before delegating and returned as errors. If calculator panics nevertheless, the panic is returned as *PanicError.
+// It returns an *InvalidArgumentError (matching ErrNilArgument) if calculator is nil.
+func NewLocalDaysCalculatorV2(calculator LocalDaysCalculator) (LocalDaysCalculatorV2, error) {
+	if calculator == nil {
+		return nil, &InvalidArgumentError{Function: "NewLocalDaysCalculatorV2", Argument: "calculator", Value: nil, Err: ErrNilArgument}
+	}
+	return localDaysCalculatorV2{calculator: calculator}, nil
+}
+
+// BuildV2 is like Build but returns a LocalDaysCalculatorV2.
+func (s CalculatorSpec) BuildV2() (LocalDaysCalculatorV2, error) {
+	calculator, err := s.Build()
+	if err != nil {
+		return nil, err
+	}
+	return NewLocalDaysCalculatorV2(calculator)
+}
+
+// inputValidator is implemented by calculators that reject some timestamps (see WithStrictInputValidation).
+type inputValidator interface {
+	// checkInput returns the error for which the calculator would panic if called with timestamp.
+	checkInput(timestamp time.Time) error
+}
+
+// checkInput returns the error for which calculator would panic if called with timestamp or nil if calculator does not implement inputValidator.
+func checkInput(calculator LocalDaysCalculator, timestamp time.Time) error {
+	if validator, ok := calculator.(inputValidator); ok {
+		return validator.checkInput(timestamp)
+	}
+	return nil
+}
+
+func (c converterBasedLocalDaysCalculator) checkInput(timestamp time.Time) error {
+	if !c.strictInputValidation {
+		return nil
+	}
+	return ValidateTimestamp(timestamp)
+}
+
+type localDaysCalculatorV2 struct {
+	calculator LocalDaysCalculator
+}
+
+// call checks timestamp and executes call while converting panics to errors.
+// The check comes first because the calculators panic using log.Panic, which logs the error before panicking, and invalid inputs are expected here.
+func (v localDaysCalculatorV2) call(method string, timestamp time.Time, call func()) (err error) {
+	if err = checkInput(v.calculator, timestamp); err != nil {
+		return err
+	}
+	defer func() {
+		if value := recover(); value != nil {
+			err = &PanicError{Method: method, Value: value}
+		}
+	}()
+	call()
+	return nil
+}
+
+func (v localDaysCalculatorV2) timeCall(name string, timestamp time.Time, method func(LocalDaysCalculator, time.Time) time.Time) (time.Time, error) {
+	var result time.Time
+	if err := v.call(name, timestamp, func() { result = method(v.calculator, timestamp) }); err != nil {
+		return time.Time{}, err
+	}
+	return result, nil
+}
+
+func (v localDaysCalculatorV2) boolCall(name string, timestamp time.Time, method func(LocalDaysCalculator, time.Time) bool) (bool, error) {
+	var result bool
+	if err := v.call(name, timestamp, func() { result = method(v.calculator, timestamp) }); err != nil {
+		return false, err
+	}
+	return result, nil
+}
+
+func (v localDaysCalculatorV2) AddLocalDays(timestamp time.Time, number int) (time.Time, error) {
+	var result time.Time
+	if err := v.call("AddLocalDays", timestamp, func() { result = v.calculator.AddLocalDays(timestamp, number) }); err != nil {
+		return time.Time{}, err
+	}
+	return result, nil
+}
+
+func (v localDaysCalculatorV2) StartOfLocalDay(timestamp time.Time) (time.Time, error) {
+	return v.timeCall("StartOfLocalDay", timestamp, LocalDaysCalculator.StartOfLocalDay)
+}
+
+func (v localDaysCalculatorV2) StartOfNextLocalDay(timestamp time.Time) (time.Time, error) {
+	return v.timeCall("StartOfNextLocalDay", timestamp, LocalDaysCalculator.StartOfNextLocalDay)
+}
+
+func (v localDaysCalculatorV2) StartOfLocalMonth(timestamp time.Time) (time.Time, error) {
+	return v.timeCall("StartOfLocalMonth", timestamp, LocalDaysCalculator.StartOfLocalMonth)
+}
+
+func (v localDaysCalculatorV2) StartOfNextLocalMonth(timestamp time.Time) (time.Time, error) {
+	return v.timeCall("StartOfNextLocalMonth", timestamp, LocalDaysCalculator.StartOfNextLocalMonth)
+}
+
+func (v localDaysCalculatorV2) GetLocalWeekday(timestamp time.Time) (time.Weekday, error) {
+	var result time.Weekday
+	if err := v.call("GetLocalWeekday", timestamp, func() { result = v.calculator.GetLocalWeekday(timestamp) }); err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+func (v localDaysCalculatorV2) NextLocalWeekday(timestamp time.Time, weekday time.Weekday) (time.Time, error) {
+	if err := validateWeekday("NextLocalWeekday", weekday); err != nil {
+		return time.Time{}, err
+	}
+	var result time.Time
+	if err := v.call("NextLocalWeekday", timestamp, func() { result = v.calculator.NextLocalWeekday(timestamp, weekday) }); err != nil {
+		return time.Time{}, err
+	}
+	return result, nil
+}
+
+func (v localDaysCalculatorV2) IsLocalMidnight(timestamp time.Time) (bool, error) {
+	return v.boolCall("IsLocalMidnight", timestamp, LocalDaysCalculator.IsLocalMidnight)
+}
+
+func (v localDaysCalculatorV2) StartOfLocalWeek(timestamp time.Time) (time.Time, error) {
+	return v.timeCall("StartOfLocalWeek", timestamp, startOfLocalWeek)
+}
+
+func (v localDaysCalculatorV2) StartOfNextLocalWeek(timestamp time.Time) (time.Time, error) {
+	return v.timeCall("StartOfNextLocalWeek", timestamp, startOfNextLocalWeek)
+}
+
+func (v localDaysCalculatorV2) IsLocalHoliday(timestamp time.Time) (bool, error) {
+	return v.boolCall("IsLocalHoliday", timestamp, isLocalHoliday)
+}
+
+func (v localDaysCalculatorV2) IsLocalBusinessDay(timestamp time.Time) (bool, error) {
+	return v.boolCall("IsLocalBusinessDay", timestamp, isLocalBusinessDay)
+}
+
+func (v localDaysCalculatorV2) Now() time.Time {
+	return now(v.calculator)
+}
+
+func (v localDaysCalculatorV2) Location() *time.Location {
+	return Location(v.calculator)
+}
+
+func (v localDaysCalculatorV2) ZoneName() string {
+	return ZoneName(v.calculator)
+}
+
+func (v localDaysCalculatorV2) V1() LocalDaysCalculator {
+	return v.calculator
+}